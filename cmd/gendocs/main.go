@@ -0,0 +1,158 @@
+// Command gendocs generates the Markdown command reference from the command registry.
+//
+// It prints the reference to stdout, or replaces the text between the
+// <!-- commands:start --> and <!-- commands:end --> markers of a file:
+//
+//	go run ./cmd/gendocs -readme README.md
+//	go run ./cmd/gendocs -readme README.md -check
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	_ "hiei-discord-bot/internal/bot"
+	"hiei-discord-bot/internal/commands"
+	"hiei-discord-bot/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	startMarker = "<!-- commands:start -->"
+	endMarker   = "<!-- commands:end -->"
+)
+
+func main() {
+	readme := flag.String("readme", "", "file whose marked section is replaced (default: print to stdout)")
+	check := flag.Bool("check", false, "only report whether the file is up to date")
+	locale := flag.String("locale", string(i18n.LocaleEnUS), "language of the generated docs")
+	flag.Parse()
+
+	if err := i18n.LoadTranslations(); err != nil {
+		fail("load translations: %v", err)
+	}
+
+	docs := render(i18n.SupportedLocale(*locale))
+	if *readme == "" {
+		fmt.Print(docs)
+		return
+	}
+
+	content, err := os.ReadFile(*readme)
+	if err != nil {
+		fail("%v", err)
+	}
+	updated, err := replaceSection(content, docs)
+	if err != nil {
+		fail("%s: %v", *readme, err)
+	}
+
+	if *check {
+		if !bytes.Equal(content, updated) {
+			fail("%s is out of date, run go run ./cmd/gendocs -readme %s", *readme, *readme)
+		}
+		return
+	}
+	if err := os.WriteFile(*readme, updated, 0o644); err != nil {
+		fail("%v", err)
+	}
+}
+
+// render writes the docs of every visible command, grouped by category
+func render(locale i18n.SupportedLocale) string {
+	byCategory := make(map[string][]commands.Doc)
+	for _, cmd := range commands.Global().All() {
+		doc := commands.Document(cmd, locale)
+		if doc.Metadata.Hidden {
+			continue
+		}
+		byCategory[doc.Metadata.Category] = append(byCategory[doc.Metadata.Category], doc)
+	}
+
+	var b strings.Builder
+	for _, category := range commands.SortCategories(byCategory) {
+		docs := byCategory[category]
+		commands.SortDocs(docs)
+
+		fmt.Fprintf(&b, "\n### %s\n", i18n.TDefault(locale, "command.help.category."+category, category))
+		for _, doc := range docs {
+			renderDoc(&b, locale, doc)
+		}
+	}
+	return b.String()
+}
+
+// renderDoc writes a command heading, its details, usages, examples and permissions
+func renderDoc(b *strings.Builder, locale i18n.SupportedLocale, doc commands.Doc) {
+	switch doc.Definition.Type {
+	case discordgo.UserApplicationCommand:
+		fmt.Fprintf(b, "\n#### %s\n\n", i18n.Tf(locale, "command.help.context.user", doc.Definition.Name))
+	case discordgo.MessageApplicationCommand:
+		fmt.Fprintf(b, "\n#### %s\n\n", i18n.Tf(locale, "command.help.context.message", doc.Definition.Name))
+	default:
+		fmt.Fprintf(b, "\n#### `/%s`\n\n", doc.Definition.Name)
+	}
+
+	b.WriteString(doc.Description + "\n")
+	if doc.Details != "" {
+		b.WriteString("\n" + doc.Details + "\n")
+	}
+	if permissions := commands.PermissionNames(locale, doc.Metadata.Permissions); permissions != "" {
+		fmt.Fprintf(b, "\n%s: %s\n", i18n.T(locale, "command.help.field.permissions"), permissions)
+	}
+	b.WriteString("\n")
+
+	switch doc.Definition.Type {
+	case discordgo.UserApplicationCommand:
+		fmt.Fprintf(b, "- %s\n", i18n.Tf(locale, "command.help.usage.user", doc.Definition.Name))
+	case discordgo.MessageApplicationCommand:
+		fmt.Fprintf(b, "- %s\n", i18n.Tf(locale, "command.help.usage.message", doc.Definition.Name))
+	default:
+		for _, usage := range doc.Usages {
+			// A command without subcommands is already described above
+			if len(usage.Path) == 0 {
+				fmt.Fprintf(b, "- `%s`\n", usage.Line)
+			} else {
+				fmt.Fprintf(b, "- `%s` - %s\n", usage.Line, usage.Description)
+			}
+			for _, opt := range usage.Options {
+				line := fmt.Sprintf("  - `%s` - %s", opt.Name, opt.Description)
+				if len(opt.Choices) > 0 {
+					line += fmt.Sprintf(" (`%s`)", strings.Join(opt.Choices, "`, `"))
+				}
+				b.WriteString(line + "\n")
+			}
+			for _, note := range usage.Notes {
+				fmt.Fprintf(b, "  - %s\n", note)
+			}
+		}
+	}
+
+	if len(doc.Examples) > 0 {
+		fmt.Fprintf(b, "\n%s:\n\n", i18n.T(locale, "command.help.field.examples"))
+		for _, example := range doc.Examples {
+			fmt.Fprintf(b, "- %s\n", example)
+		}
+	}
+}
+
+// replaceSection swaps the text between the markers for docs
+func replaceSection(content []byte, docs string) ([]byte, error) {
+	text := string(content)
+	start := strings.Index(text, startMarker)
+	end := strings.Index(text, endMarker)
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("missing %s and %s markers", startMarker, endMarker)
+	}
+
+	return []byte(text[:start+len(startMarker)] + "\n" + docs + "\n" + text[end:]), nil
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "gendocs: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,204 @@
+package blames
+
+import (
+	"errors"
+	"fmt"
+	"hiei-discord-bot/internal/models"
+	"sync"
+	"time"
+)
+
+const (
+	// TopReasonsLimit is the number of reasons shown in a user's stats
+	TopReasonsLimit = 5
+
+	// LeaderboardLimit is the number of users shown on the leaderboard
+	LeaderboardLimit = 10
+
+	// MaxImagesPerGuild is the number of custom images a guild can upload
+	MaxImagesPerGuild = 20
+
+	// MaxImageSize is the largest custom image accepted, in bytes
+	MaxImageSize = 4 << 20
+)
+
+var (
+	ErrImageNotFound = errors.New("blame image not found")
+	ErrImageLimit    = errors.New("blame image limit reached")
+	ErrImageTooLarge = errors.New("blame image too large")
+)
+
+// Store interface for persistence
+type Store interface {
+	AddBlame(blame models.Blame) (int64, error)
+	CountBlamesReceived(guildID, userID string) (int, error)
+	CountBlamesIssued(guildID, userID string) (int, error)
+	TopBlameReasons(guildID, targetID string, limit int) ([]models.BlameReasonCount, error)
+	MostBlamedUsers(guildID string, since time.Time, limit int) ([]models.BlameUserCount, error)
+	AddBlameImage(image models.BlameImage) (int64, error)
+	ListBlameImages(guildID string) ([]models.BlameImage, error)
+	GetBlameImage(guildID string, id int64) (*models.BlameImage, error)
+	DeleteBlameImage(guildID string, id int64) (bool, error)
+	VoteStore
+}
+
+// Stats summarizes the blames a user received and issued in a guild
+type Stats struct {
+	Received   int
+	Issued     int
+	TopReasons []models.BlameReasonCount
+}
+
+// Manager records blames and answers history queries
+type Manager struct {
+	store Store
+	mu    sync.RWMutex
+}
+
+var instance *Manager
+var once sync.Once
+
+// GetManager returns the singleton manager instance
+func GetManager() *Manager {
+	once.Do(func() {
+		instance = &Manager{}
+	})
+	return instance
+}
+
+// SetStore sets the storage engine
+func (mgr *Manager) SetStore(s Store) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.store = s
+}
+
+// Record stores a blame and returns its ID
+func (mgr *Manager) Record(blame models.Blame) (int64, error) {
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+
+	if mgr.store == nil {
+		return 0, fmt.Errorf("store not initialized")
+	}
+	if blame.CreatedAt.IsZero() {
+		blame.CreatedAt = time.Now().UTC()
+	}
+	return mgr.store.AddBlame(blame)
+}
+
+// UserStats returns the received and issued counts and the top reasons of a user in a guild
+func (mgr *Manager) UserStats(guildID, userID string) (*Stats, error) {
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+
+	received, err := mgr.store.CountBlamesReceived(guildID, userID)
+	if err != nil {
+		return nil, err
+	}
+	issued, err := mgr.store.CountBlamesIssued(guildID, userID)
+	if err != nil {
+		return nil, err
+	}
+	reasons, err := mgr.store.TopBlameReasons(guildID, userID, TopReasonsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stats{
+		Received:   received,
+		Issued:     issued,
+		TopReasons: reasons,
+	}, nil
+}
+
+// Leaderboard returns the most blamed users of a guild since the given time.
+// A zero time covers the whole history.
+func (mgr *Manager) Leaderboard(guildID string, since time.Time) ([]models.BlameUserCount, error) {
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return mgr.store.MostBlamedUsers(guildID, since, LeaderboardLimit)
+}
+
+// AddImage stores a custom image for a guild and returns its ID
+func (mgr *Manager) AddImage(image models.BlameImage) (int64, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return 0, fmt.Errorf("store not initialized")
+	}
+	if len(image.Data) > MaxImageSize {
+		return 0, ErrImageTooLarge
+	}
+
+	images, err := mgr.store.ListBlameImages(image.GuildID)
+	if err != nil {
+		return 0, err
+	}
+	if len(images) >= MaxImagesPerGuild {
+		return 0, ErrImageLimit
+	}
+
+	if image.CreatedAt.IsZero() {
+		image.CreatedAt = time.Now().UTC()
+	}
+	return mgr.store.AddBlameImage(image)
+}
+
+// Images lists the custom images of a guild without their data
+func (mgr *Manager) Images(guildID string) ([]models.BlameImage, error) {
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return mgr.store.ListBlameImages(guildID)
+}
+
+// RemoveImage deletes a custom image of a guild
+func (mgr *Manager) RemoveImage(guildID string, id int64) error {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+
+	deleted, err := mgr.store.DeleteBlameImage(guildID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrImageNotFound
+	}
+	return nil
+}
+
+// Image returns a custom image of a guild including its data
+func (mgr *Manager) Image(guildID string, id int64) (*models.BlameImage, error) {
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+
+	image, err := mgr.store.GetBlameImage(guildID, id)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, ErrImageNotFound
+	}
+	return image, nil
+}
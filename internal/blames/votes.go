@@ -0,0 +1,240 @@
+package blames
+
+import (
+	"errors"
+	"fmt"
+	"hiei-discord-bot/internal/models"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Vote kinds: a blame vote confirms a blame, an appeal vote overturns it
+const (
+	VoteKindBlame  = "blame"
+	VoteKindAppeal = "appeal"
+)
+
+// Vote statuses
+const (
+	VoteOpen   = "open"
+	VotePassed = "passed"
+	VoteFailed = "failed"
+)
+
+var (
+	ErrVoteNotFound = errors.New("blame vote not found")
+	ErrVoteClosed   = errors.New("blame vote closed")
+	ErrNotEligible  = errors.New("user cannot vote on this blame")
+	ErrNotTarget    = errors.New("only the blamed user can appeal")
+	ErrNotConfirmed = errors.New("blame is not confirmed")
+	ErrAppealExists = errors.New("blame was already appealed")
+	ErrNotBlameVote = errors.New("vote is not a blame vote")
+)
+
+// VoteStore interface for vote persistence
+type VoteStore interface {
+	SaveBlameVote(vote models.BlameVote) error
+	GetBlameVote(id string) (*models.BlameVote, error)
+	GetBlameVoteByBlame(blameID int64, kind string) (*models.BlameVote, error)
+	ListOpenBlameVotes() ([]models.BlameVote, error)
+	SetBlameBallot(voteID, userID string, agree bool, castAt time.Time) error
+	CountBlameBallots(voteID string) (int, int, error)
+}
+
+// Tally counts the ballots of a vote
+type Tally struct {
+	Agree    int
+	Disagree int
+}
+
+// Net returns the agree votes minus the disagree votes
+func (t Tally) Net() int {
+	return t.Agree - t.Disagree
+}
+
+// NewVoteID returns a short random vote ID
+func NewVoteID() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+}
+
+// StartVote opens a vote on a blame or an appeal
+func (mgr *Manager) StartVote(vote *models.BlameVote) error {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+
+	vote.Status = VoteOpen
+	if vote.CreatedAt.IsZero() {
+		vote.CreatedAt = time.Now().UTC()
+	}
+	return mgr.store.SaveBlameVote(*vote)
+}
+
+// Vote returns a vote and its current tally
+func (mgr *Manager) Vote(id string) (*models.BlameVote, Tally, error) {
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+
+	if mgr.store == nil {
+		return nil, Tally{}, fmt.Errorf("store not initialized")
+	}
+	return mgr.getVote(id)
+}
+
+// OpenVotes returns every vote that has not been decided
+func (mgr *Manager) OpenVotes() ([]models.BlameVote, error) {
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return mgr.store.ListOpenBlameVotes()
+}
+
+// Cast records a user's ballot, replacing an earlier one, and passes the vote
+// as soon as the net agree votes reach its threshold.
+// The blamed user and the blamer cannot vote.
+func (mgr *Manager) Cast(id, userID string, agree bool) (*models.BlameVote, Tally, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return nil, Tally{}, fmt.Errorf("store not initialized")
+	}
+
+	vote, _, err := mgr.getVote(id)
+	if err != nil {
+		return nil, Tally{}, err
+	}
+	if vote.Status != VoteOpen || !time.Now().Before(vote.EndsAt) {
+		return nil, Tally{}, ErrVoteClosed
+	}
+	if userID == vote.TargetID || userID == vote.BlamerID {
+		return nil, Tally{}, ErrNotEligible
+	}
+
+	if err := mgr.store.SetBlameBallot(id, userID, agree, time.Now()); err != nil {
+		return nil, Tally{}, err
+	}
+	tally, err := mgr.tally(id)
+	if err != nil {
+		return nil, Tally{}, err
+	}
+
+	if tally.Net() >= vote.Threshold {
+		vote.Status = VotePassed
+		if err := mgr.store.SaveBlameVote(*vote); err != nil {
+			return nil, Tally{}, err
+		}
+	}
+	return vote, tally, nil
+}
+
+// Close decides a vote whose time limit has passed
+func (mgr *Manager) Close(id string) (*models.BlameVote, Tally, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return nil, Tally{}, fmt.Errorf("store not initialized")
+	}
+
+	vote, tally, err := mgr.getVote(id)
+	if err != nil {
+		return nil, Tally{}, err
+	}
+	if vote.Status != VoteOpen {
+		return nil, Tally{}, ErrVoteClosed
+	}
+
+	vote.Status = VoteFailed
+	if tally.Net() >= vote.Threshold {
+		vote.Status = VotePassed
+	}
+	if err := mgr.store.SaveBlameVote(*vote); err != nil {
+		return nil, Tally{}, err
+	}
+	return vote, tally, nil
+}
+
+// Appeal opens a counter-vote on a confirmed blame. Only the blamed user can
+// appeal, and only once per blame.
+func (mgr *Manager) Appeal(blameVoteID, userID, reason string, threshold int, endsAt time.Time) (*models.BlameVote, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+
+	blameVote, _, err := mgr.getVote(blameVoteID)
+	if err != nil {
+		return nil, err
+	}
+	if blameVote.Kind != VoteKindBlame {
+		return nil, ErrNotBlameVote
+	}
+	if userID != blameVote.TargetID {
+		return nil, ErrNotTarget
+	}
+	if blameVote.Status != VotePassed {
+		return nil, ErrNotConfirmed
+	}
+
+	existing, err := mgr.store.GetBlameVoteByBlame(blameVote.BlameID, VoteKindAppeal)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAppealExists
+	}
+
+	appeal := models.BlameVote{
+		ID:        NewVoteID(),
+		BlameID:   blameVote.BlameID,
+		Kind:      VoteKindAppeal,
+		GuildID:   blameVote.GuildID,
+		ChannelID: blameVote.ChannelID,
+		MessageID: blameVote.MessageID,
+		TargetID:  blameVote.TargetID,
+		BlamerID:  blameVote.BlamerID,
+		Reason:    reason,
+		Threshold: threshold,
+		Status:    VoteOpen,
+		Locale:    blameVote.Locale,
+		EndsAt:    endsAt,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := mgr.store.SaveBlameVote(appeal); err != nil {
+		return nil, err
+	}
+	return &appeal, nil
+}
+
+// getVote loads a vote with its tally; the caller must hold the lock
+func (mgr *Manager) getVote(id string) (*models.BlameVote, Tally, error) {
+	vote, err := mgr.store.GetBlameVote(id)
+	if err != nil {
+		return nil, Tally{}, err
+	}
+	if vote == nil {
+		return nil, Tally{}, ErrVoteNotFound
+	}
+
+	tally, err := mgr.tally(id)
+	if err != nil {
+		return nil, Tally{}, err
+	}
+	return vote, tally, nil
+}
+
+func (mgr *Manager) tally(id string) (Tally, error) {
+	agree, disagree, err := mgr.store.CountBlameBallots(id)
+	return Tally{Agree: agree, Disagree: disagree}, err
+}
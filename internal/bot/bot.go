@@ -7,12 +7,17 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
+	"hiei-discord-bot/internal/blames"
 	"hiei-discord-bot/internal/commands"
 	"hiei-discord-bot/internal/config"
+	"hiei-discord-bot/internal/economy"
 	"hiei-discord-bot/internal/events"
+	"hiei-discord-bot/internal/giveaways"
+	"hiei-discord-bot/internal/highscores"
+	"hiei-discord-bot/internal/hotreload"
 	"hiei-discord-bot/internal/interactions"
+	"hiei-discord-bot/internal/scheduler"
 	"hiei-discord-bot/internal/settings"
 	"hiei-discord-bot/internal/settings/store"
 
@@ -35,11 +40,10 @@ func New(cfg *config.Config) (*Bot, error) {
 
 	// Configure HTTP client with timeout
 	session.Client = &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout: cfg.HTTPTimeout,
 	}
 
-	// Set intents - we need guilds for slash commands
-	session.Identify.Intents = discordgo.IntentsGuilds
+	session.Identify.Intents = cfg.IntentFlags()
 
 	bot := &Bot{
 		session:  session,
@@ -48,11 +52,15 @@ func New(cfg *config.Config) (*Bot, error) {
 	}
 
 	// Initialize settings store
-	sqliteStore, err := store.NewSQLiteStore("database.db")
+	sqliteStore, err := store.NewSQLiteStore(cfg.DatabasePath)
 	if err != nil {
 		slog.Error("Failed to initialize settings store", "error", err)
 	} else {
 		settings.GetManager().SetStore(sqliteStore)
+		economy.GetManager().SetStore(sqliteStore)
+		highscores.GetManager().SetStore(sqliteStore)
+		giveaways.GetManager().SetStore(sqliteStore)
+		blames.GetManager().SetStore(sqliteStore)
 		slog.Info("Settings store initialized")
 	}
 
@@ -64,7 +72,7 @@ func New(cfg *config.Config) (*Bot, error) {
 	session.AddHandler(bot.handleInteraction)
 
 	// Register event handlers
-	eventHandler := events.NewHandler(session, bot.registry)
+	eventHandler := events.NewHandler(session, bot.registry, cfg)
 	eventHandler.Register()
 
 	return bot, nil
@@ -76,6 +84,21 @@ func (bot *Bot) registerCommands() {
 	// and blank-imported in internal/bot/commands.go
 }
 
+// syncGlobalCommands registers the global commands in global mode, and removes
+// them in the other modes if an earlier run registered them
+func (bot *Bot) syncGlobalCommands() {
+	if bot.config.CommandRegistration != config.RegistrationGlobal {
+		if err := commands.RemoveGuildCommands(bot.session, commands.GlobalScope); err != nil {
+			slog.Error("Failed to remove global commands", "error", err)
+		}
+		return
+	}
+
+	if err := commands.SyncGlobalCommands(bot.session, bot.registry, false); err != nil {
+		slog.Error("Failed to sync global commands", "error", err)
+	}
+}
+
 // handleInteraction handles component and modal interactions
 func (bot *Bot) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	router := interactions.GetRouter()
@@ -90,27 +113,43 @@ func (bot *Bot) handleInteraction(s *discordgo.Session, i *discordgo.Interaction
 
 // Start starts the bot and blocks until interrupted
 func (bot *Bot) Start() error {
+	// Sync local command versions before guilds arrive, so their syncs see the changes
+	if err := commands.SyncLocalCommandVersions(bot.registry); err != nil {
+		slog.Error("Failed to sync local command versions", "error", err)
+	}
+
 	if err := bot.session.Open(); err != nil {
 		return fmt.Errorf("failed to open Discord session: %w", err)
 	}
+	bot.syncGlobalCommands()
 
-	// Sync local command versions at startup
-	if err := commands.SyncLocalCommandVersions(bot.registry); err != nil {
-		slog.Error("Failed to sync local command versions", "error", err)
-	}
+	// Start scheduled jobs, restoring those persisted before the last shutdown
+	scheduler.Get().Start(bot.session)
 
 	slog.Info("Bot is now running. Press CTRL+C to exit.")
 
-	// Wait for interrupt signal
+	// Wait for interrupt signal, reloading the configuration on SIGHUP
 	sc := make(chan os.Signal, 1)
 	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
-	<-sc
-
-	return bot.Stop()
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	for {
+		select {
+		case <-hup:
+			slog.Info("Received SIGHUP, reloading configuration")
+			if _, err := hotreload.Reload(); err != nil {
+				slog.Error("Failed to reload configuration, keeping the current one", "error", err)
+			}
+		case <-sc:
+			return bot.Stop()
+		}
+	}
 }
 
 // Stop gracefully stops the bot
 func (bot *Bot) Stop() error {
 	slog.Info("Shutting down bot...")
+	scheduler.Get().Stop()
 	return bot.session.Close()
 }
@@ -2,12 +2,20 @@ package bot
 
 import (
 	_ "hiei-discord-bot/internal/commands/blame"
+	_ "hiei-discord-bot/internal/commands/botconfig"
 	_ "hiei-discord-bot/internal/commands/game"
+	_ "hiei-discord-bot/internal/commands/game/games/blackjack"
 	_ "hiei-discord-bot/internal/commands/game/games/bullsandcows"
+	_ "hiei-discord-bot/internal/commands/game/games/game2048"
+	_ "hiei-discord-bot/internal/commands/game/games/hangman"
+	_ "hiei-discord-bot/internal/commands/game/games/minesweeper"
+	_ "hiei-discord-bot/internal/commands/game/games/trivia"
 	_ "hiei-discord-bot/internal/commands/game/games/wordle"
+	_ "hiei-discord-bot/internal/commands/giveaway"
 	_ "hiei-discord-bot/internal/commands/help"
 	_ "hiei-discord-bot/internal/commands/ping"
 	_ "hiei-discord-bot/internal/commands/preferences"
 	_ "hiei-discord-bot/internal/commands/random"
 	_ "hiei-discord-bot/internal/commands/reload"
+	_ "hiei-discord-bot/internal/commands/status"
 )
@@ -0,0 +1,58 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Info describes the running binary
+type Info struct {
+	GoVersion string
+	Commit    string    // VCS revision, empty when the build was not stamped
+	Time      time.Time // Commit time, zero when unknown
+	Modified  bool      // Built from a working tree with uncommitted changes
+}
+
+var (
+	info     Info
+	infoOnce sync.Once
+)
+
+// Get returns the build information stamped by the Go toolchain.
+// Binaries built with go build inside the repository carry the VCS revision;
+// go run and builds outside a checkout do not.
+func Get() Info {
+	infoOnce.Do(func() {
+		info.GoVersion = runtime.Version()
+
+		build, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				info.Commit = setting.Value
+			case "vcs.time":
+				info.Time, _ = time.Parse(time.RFC3339, setting.Value)
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	})
+	return info
+}
+
+// ShortCommit returns the first 12 characters of the commit, or "unknown"
+func (i Info) ShortCommit() string {
+	switch {
+	case i.Commit == "":
+		return "unknown"
+	case len(i.Commit) > 12:
+		return i.Commit[:12]
+	default:
+		return i.Commit
+	}
+}
@@ -0,0 +1,169 @@
+// Package cli implements the management subcommands of the bot binary. They
+// reuse the command registry, settings manager and store, and only talk to
+// Discord over REST, so no gateway session is opened.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+
+	_ "hiei-discord-bot/internal/bot" // Registers the commands and their settings
+	"hiei-discord-bot/internal/buildinfo"
+	"hiei-discord-bot/internal/config"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/settings"
+	"hiei-discord-bot/internal/settings/store"
+)
+
+const usage = `Usage: hiei-bot [command]
+
+Without a command the bot starts and connects to Discord.
+
+Commands:
+  sync [-guild ID] [-command KEY] [-dry-run]  Sync slash commands with Discord
+  commands list                               List registered commands
+  settings list                               List setting definitions
+  settings get KEY TARGET                     Show a setting of a guild or user
+  settings set KEY TARGET VALUE               Change a setting of a guild or user
+  db migrate                                  Create or upgrade the database tables
+  db backup FILE                              Write a copy of the database to FILE
+  i18n check                                  Report missing or mismatched translations
+  config print                                Show the effective configuration, secrets redacted
+  version                                     Show build information
+`
+
+// errUsage reports invalid arguments, answered with the usage text
+var errUsage = errors.New("invalid arguments")
+
+// Run executes a subcommand and returns the process exit code
+func Run(args []string) int {
+	// Keep the command output readable; warnings and errors still reach stderr
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	})))
+
+	if err := run(args); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(os.Stderr, usage)
+			return 2
+		}
+		fmt.Fprintf(os.Stderr, "hiei-bot: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "sync":
+		return runSync(args)
+	case "version":
+		return runVersion(args)
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+		return nil
+	}
+
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch command + " " + args[0] {
+	case "commands list":
+		return runCommandsList(args[1:])
+	case "settings list":
+		return runSettingsList(args[1:])
+	case "settings get":
+		return runSettingsGet(args[1:])
+	case "settings set":
+		return runSettingsSet(args[1:])
+	case "db migrate":
+		return runDBMigrate(args[1:])
+	case "db backup":
+		return runDBBackup(args[1:])
+	case "i18n check":
+		return runI18nCheck(args[1:])
+	case "config print":
+		return runConfigPrint(args[1:])
+	}
+	return errUsage
+}
+
+// runVersion prints the build the binary was made from
+func runVersion(args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+
+	build := buildinfo.Get()
+	commit := build.ShortCommit()
+	if build.Modified {
+		commit += "-dirty"
+	}
+	fmt.Printf("hiei-bot %s (%s", commit, build.GoVersion)
+	if !build.Time.IsZero() {
+		fmt.Printf(", committed %s", build.Time.UTC().Format("2006-01-02 15:04:05 MST"))
+	}
+	fmt.Println(")")
+	return nil
+}
+
+// runI18nCheck reports untranslated keys and mismatched format verbs
+func runI18nCheck(args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+	if err := i18n.LoadTranslations(); err != nil {
+		return err
+	}
+
+	problems := i18n.Check()
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d translation problem(s)", len(problems))
+	}
+	fmt.Println("Translations are complete")
+	return nil
+}
+
+// runConfigPrint shows the configuration the bot would start with
+func runConfigPrint(args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+
+	cfg, err := config.LoadLocal()
+	if err != nil {
+		return err
+	}
+	return cfg.Print(os.Stdout)
+}
+
+// openStore opens the existing configured database and hands it to the settings
+// manager. Only db migrate creates or upgrades the database, so a wrong path
+// fails instead of creating an empty database.
+func openStore(readOnly bool) (*store.SQLiteStore, error) {
+	cfg, err := config.LoadLocal()
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteStore, err := store.OpenSQLiteStore(cfg.DatabasePath, readOnly)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("database %s does not exist, create it with db migrate", cfg.DatabasePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", cfg.DatabasePath, err)
+	}
+	settings.GetManager().SetStore(sqliteStore)
+	return sqliteStore, nil
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"hiei-discord-bot/internal/config"
+	"hiei-discord-bot/internal/settings/store"
+)
+
+// runDBMigrate creates missing tables and columns, as the bot does on startup
+func runDBMigrate(args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+
+	cfg, err := config.LoadLocal()
+	if err != nil {
+		return err
+	}
+
+	sqliteStore, err := store.NewSQLiteStore(cfg.DatabasePath)
+	if err != nil {
+		return fmt.Errorf("failed to migrate database %s: %w", cfg.DatabasePath, err)
+	}
+	defer sqliteStore.Close()
+
+	fmt.Printf("Database %s is up to date\n", cfg.DatabasePath)
+	return nil
+}
+
+// runDBBackup writes a consistent copy of the database, safe while the bot runs
+func runDBBackup(args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	path := args[0]
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+
+	// Back up the database as it is, without upgrading it first
+	sqliteStore, err := openStore(true)
+	if err != nil {
+		return err
+	}
+	defer sqliteStore.Close()
+
+	if err := sqliteStore.Backup(path); err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	fmt.Printf("Backed up %s to %s\n", config.Get().DatabasePath, path)
+	return nil
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/settings"
+)
+
+// runSettingsList prints every registered setting with its scope, type and default
+func runSettingsList(args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+
+	defs := definitions()
+	sort.Slice(defs, func(a, b int) bool { return defs[a].Key < defs[b].Key })
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tSCOPE\tTYPE\tDEFAULT")
+	for _, def := range defs {
+		typ := string(def.Type)
+		if len(def.Options) > 0 {
+			typ += " (" + strings.Join(def.Options, ", ") + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\n", def.Key, def.Scope, typ, quote(fmt.Sprint(def.Default)))
+	}
+	return w.Flush()
+}
+
+// runSettingsGet prints the value of a setting for a guild or user, or its default
+func runSettingsGet(args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	key, target := args[0], args[1]
+
+	def, err := definition(key)
+	if err != nil {
+		return err
+	}
+	if _, err := openStore(true); err != nil {
+		return err
+	}
+
+	value, err := settings.GetManager().GetSettingValue(def.Scope, target, key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	return nil
+}
+
+// runSettingsSet validates and stores the value of a setting for a guild or user
+func runSettingsSet(args []string) error {
+	if len(args) != 3 {
+		return errUsage
+	}
+	key, target, value := args[0], args[1], args[2]
+
+	def, err := definition(key)
+	if err != nil {
+		return err
+	}
+	if err := checkValue(def, value); err != nil {
+		return err
+	}
+	if _, err := openStore(false); err != nil {
+		return err
+	}
+
+	if err := settings.GetManager().SetSettingValue(def.Scope, target, key, value); err != nil {
+		return err
+	}
+	fmt.Printf("Set %s for %s %s to %s\n", key, def.Scope, target, quote(value))
+	return nil
+}
+
+// definitions returns every setting definition, including the user language
+func definitions() []settings.SettingDefinition {
+	// The language setting is registered along with the locale store
+	i18n.GetStore()
+	return settings.GetManager().GetDefinitions()
+}
+
+func definition(key string) (settings.SettingDefinition, error) {
+	for _, def := range definitions() {
+		if def.Key == key {
+			return def, nil
+		}
+	}
+	return settings.SettingDefinition{}, fmt.Errorf("setting %s not found", key)
+}
+
+// checkValue applies the checks the settings menus get from their inputs
+func checkValue(def settings.SettingDefinition, value string) error {
+	switch def.Type {
+	case settings.TypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%s must be a number", def.Key)
+		}
+	case settings.TypeBool:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%s must be true or false", def.Key)
+		}
+	case settings.TypeSelect:
+		if !slices.Contains(def.Options, value) {
+			return fmt.Errorf("%s must be one of %s", def.Key, strings.Join(def.Options, ", "))
+		}
+	}
+	return nil
+}
+
+// quote shows empty values so they stand out in the output
+func quote(value string) string {
+	if value == "" {
+		return `""`
+	}
+	return value
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"hiei-discord-bot/internal/commands"
+	"hiei-discord-bot/internal/config"
+	"hiei-discord-bot/internal/settings"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/mod/semver"
+)
+
+// runSync plans and applies command changes over REST. Without -guild it syncs
+// the scopes of the configured registration mode.
+func runSync(args []string) error {
+	flags := flag.NewFlagSet("sync", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	guildID := flags.String("guild", "", "only sync this guild")
+	command := flags.String("command", "", "only sync this command, e.g. help or user:blame")
+	dryRun := flags.Bool("dry-run", false, "print the changes without applying them")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return errUsage
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if _, err := openStore(false); err != nil {
+		return err
+	}
+
+	session, err := restSession(cfg)
+	if err != nil {
+		return err
+	}
+
+	scopes := []string{*guildID}
+	if *guildID == "" {
+		if scopes, err = registrationScopes(session, cfg); err != nil {
+			return err
+		}
+	}
+
+	registry := commands.Global()
+	if !*dryRun {
+		if err := commands.SyncLocalCommandVersions(registry); err != nil {
+			return err
+		}
+	}
+
+	key := strings.ToLower(strings.TrimPrefix(*command, "/"))
+	for _, scope := range scopes {
+		plan, err := commands.PlanGuildSync(session, registry, scope, key)
+		if errors.Is(err, commands.ErrUnknownCommand) {
+			return fmt.Errorf("unknown command %s", *command)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", scopeName(scope), err)
+		}
+
+		printPlan(plan)
+		if *dryRun {
+			continue
+		}
+		if err := commands.ApplyGuildSync(session, plan); err != nil {
+			return fmt.Errorf("%s: %w", scopeName(scope), err)
+		}
+	}
+	return nil
+}
+
+// restSession creates a session for REST calls only, identifying the application
+// the way the gateway's ready event would
+func restSession(cfg *config.Config) (*discordgo.Session, error) {
+	session, err := discordgo.New("Bot " + cfg.DiscordToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Discord session: %w", err)
+	}
+
+	user, err := session.User("@me")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the bot user: %w", err)
+	}
+	session.State.User = user
+	return session, nil
+}
+
+// registrationScopes returns the guilds, or the global scope, the registration mode syncs
+func registrationScopes(session *discordgo.Session, cfg *config.Config) ([]string, error) {
+	switch cfg.CommandRegistration {
+	case config.RegistrationGlobal:
+		return []string{commands.GlobalScope}, nil
+	case config.RegistrationDev:
+		return cfg.DevGuildIDs, nil
+	}
+
+	var guildIDs []string
+	after := ""
+	for {
+		guilds, err := session.UserGuilds(200, "", after, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch guilds: %w", err)
+		}
+		for _, guild := range guilds {
+			guildIDs = append(guildIDs, guild.ID)
+		}
+		if len(guilds) < 200 {
+			return guildIDs, nil
+		}
+		after = guilds[len(guilds)-1].ID
+	}
+}
+
+// printPlan lists the changes of a plan, one command per line
+func printPlan(plan *commands.SyncPlan) {
+	fmt.Printf("%s: %d change(s), %d unchanged\n", scopeName(plan.GuildID), len(plan.Changes), len(plan.Unchanged))
+	for _, change := range plan.Changes {
+		line := fmt.Sprintf("  %-6s %s", change.Kind, change.Key)
+		if len(change.Fields) > 0 {
+			line += ": " + strings.Join(change.Fields, ", ")
+		}
+		fmt.Println(line)
+	}
+}
+
+func scopeName(guildID string) string {
+	if guildID == commands.GlobalScope {
+		return "global"
+	}
+	return "guild " + guildID
+}
+
+// runCommandsList prints the registered commands and whether the version table
+// has recorded their current definition
+func runCommandsList(args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+	if _, err := openStore(true); err != nil {
+		return err
+	}
+
+	mgr := settings.GetManager()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVERSION\tCATEGORY\tHASH\tSTATE")
+	for _, cmd := range commands.Global().All() {
+		def := cmd.Definition()
+		key := commands.Key(def)
+		version := "v" + cmd.Version()
+		hash := commands.Hash(def)
+
+		stored, err := mgr.GetLocalCommandVersionAndBuildTime(key)
+		if err != nil {
+			return err
+		}
+		state := "current"
+		switch {
+		case stored.Version == "":
+			state = "new"
+		case semver.Compare(version, stored.Version) > 0 || (stored.Hash != "" && stored.Hash != hash):
+			state = "changed"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", key, version, commands.Describe(cmd).Category, hash[:12], state)
+	}
+	return w.Flush()
+}
@@ -1,15 +1,12 @@
 package blame
 
 import (
-	"bytes"
 	"fmt"
-	"io"
 
 	"hiei-discord-bot/internal/commands"
 	"hiei-discord-bot/internal/i18n"
 	"hiei-discord-bot/internal/interactions"
 	"hiei-discord-bot/internal/settings"
-	"hiei-discord-bot/resources"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -39,6 +36,70 @@ func (c *Command) Settings() []settings.SettingDefinition {
 			DescKey:            "setting.blame.channel.desc",
 			RequiredPermission: discordgo.PermissionAdministrator,
 		},
+		{
+			Key:                "blame_templates",
+			Module:             "blame",
+			Scope:              settings.ScopeGuild,
+			Type:               settings.TypeText,
+			Default:            "",
+			Validator:          validateTemplates,
+			LabelKey:           "setting.blame.templates.label",
+			DescKey:            "setting.blame.templates.desc",
+			RequiredPermission: discordgo.PermissionAdministrator,
+		},
+		{
+			Key:                "blame_image_mode",
+			Module:             "blame",
+			Scope:              settings.ScopeGuild,
+			Type:               settings.TypeSelect,
+			Default:            imageModeCustom,
+			Options:            []string{imageModeCustom, imageModeMixed, imageModeDefault},
+			LabelKey:           "setting.blame.image_mode.label",
+			DescKey:            "setting.blame.image_mode.desc",
+			RequiredPermission: discordgo.PermissionAdministrator,
+		},
+		{
+			Key:      "blame_allow",
+			Module:   "blame",
+			Scope:    settings.ScopeUser,
+			Type:     settings.TypeSelect,
+			Default:  allowEveryone,
+			Options:  []string{allowEveryone, allowSharedRole, allowNobody},
+			LabelKey: "setting.blame.allow.label",
+			DescKey:  "setting.blame.allow.desc",
+		},
+		{
+			Key:                "blame_vote",
+			Module:             "blame",
+			Scope:              settings.ScopeGuild,
+			Type:               settings.TypeBool,
+			Default:            false,
+			LabelKey:           "setting.blame.vote.label",
+			DescKey:            "setting.blame.vote.desc",
+			RequiredPermission: discordgo.PermissionAdministrator,
+		},
+		{
+			Key:                "blame_vote_threshold",
+			Module:             "blame",
+			Scope:              settings.ScopeGuild,
+			Type:               settings.TypeInt,
+			Default:            3,
+			Validator:          settings.IntRange(1, maxVoteThreshold),
+			LabelKey:           "setting.blame.vote_threshold.label",
+			DescKey:            "setting.blame.vote_threshold.desc",
+			RequiredPermission: discordgo.PermissionAdministrator,
+		},
+		{
+			Key:                "blame_vote_minutes",
+			Module:             "blame",
+			Scope:              settings.ScopeGuild,
+			Type:               settings.TypeInt,
+			Default:            60,
+			Validator:          settings.IntRange(1, maxVoteMinutes),
+			LabelKey:           "setting.blame.vote_minutes.label",
+			DescKey:            "setting.blame.vote_minutes.desc",
+			RequiredPermission: discordgo.PermissionAdministrator,
+		},
 	}
 }
 
@@ -49,16 +110,116 @@ func (c *Command) Definition() *discordgo.ApplicationCommand {
 		Description: "Severely condemn someone",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
-				Type:        discordgo.ApplicationCommandOptionUser,
-				Name:        "target",
-				Description: "The user to blame",
-				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "user",
+				Description: "Blame someone",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "target",
+						Description: "The user to blame",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "reason",
+						Description: "The reason for blaming",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "template",
+						Description: "Number of the server template to use (default: random)",
+						Required:    false,
+						MinValue:    float64Ptr(1),
+						MaxValue:    maxTemplates,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "stats",
+				Description: "Show how often someone was blamed and blamed others",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "user",
+						Description: "The user to look up (default: you)",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "leaderboard",
+				Description: "Show the most blamed members of this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "period",
+						Description: "Time window (default: 30 days)",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{
+								Name:  "7 days",
+								Value: "7d",
+							},
+							{
+								Name:  "30 days",
+								Value: "30d",
+							},
+							{
+								Name:  "All time",
+								Value: "all",
+							},
+						},
+					},
+				},
 			},
 			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "reason",
-				Description: "The reason for blaming",
-				Required:    true,
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:        "image",
+				Description: "Manage the server's blame images (Manage Server)",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "add",
+						Description: "Upload a blame image",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionAttachment,
+								Name:        "file",
+								Description: "PNG, JPEG, GIF or WebP image, at most 4 MB",
+								Required:    true,
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "name",
+								Description: "Name shown in the image list",
+								Required:    false,
+								MaxLength:   maxImageNameLength,
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "list",
+						Description: "List the uploaded blame images",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "remove",
+						Description: "Delete an uploaded blame image",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								Name:        "id",
+								Description: "ID of the image, see /blame image list",
+								Required:    true,
+							},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -66,30 +227,66 @@ func (c *Command) Definition() *discordgo.ApplicationCommand {
 
 // Version returns the command version
 func (c *Command) Version() string {
-	return "1.0.0"
+	return "2.1.0"
+}
+
+// Describe returns the help metadata
+func (c *Command) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryFun}
 }
 
 // Execute runs the blame command
 func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	locale := i18n.GetUserLocaleFromInteraction(i)
 	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return nil
+	}
 
+	subcommand := options[0]
+	switch subcommand.Name {
+	case "user":
+		return handleBlame(s, i, locale, subcommand.Options)
+	case "stats":
+		return handleStats(s, i, locale, subcommand.Options)
+	case "leaderboard":
+		return handleLeaderboard(s, i, locale, subcommand.Options)
+	case "image":
+		return handleImage(s, i, locale, subcommand.Options)
+	}
+
+	return interactions.RespondError(s, i, locale, "command.unknown", true)
+}
+
+// handleBlame posts a blame and records it in the blame history
+func handleBlame(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
 	// Extract parameters
 	var targetUser *discordgo.User
 	var reason string
-
+	var templateIndex int
 	for _, opt := range options {
 		switch opt.Name {
 		case "target":
 			targetUser = opt.UserValue(s)
 		case "reason":
 			reason = opt.StringValue()
+		case "template":
+			templateIndex = int(opt.IntValue())
 		}
 	}
 
 	if targetUser == nil {
 		return interactions.RespondError(s, i, locale, "blame.error.no_target", true)
 	}
+	if key := blameRefusal(s, i, targetUser); key != "" {
+		return interactions.RespondError(s, i, locale, key, true, targetUser.Username)
+	}
+
+	if templateIndex > 0 {
+		if count := len(guildTemplates(i.GuildID)); templateIndex > count {
+			return interactions.RespondError(s, i, locale, "blame.error.template_out_of_range", true, templateIndex, count)
+		}
+	}
 
 	// Defer response to buy time for processing
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -99,18 +296,8 @@ func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate)
 		return err
 	}
 
-	// Read the blame image from embedded resources
-	imageData, err := resources.Images.Open(resources.ImagesBasePath + "/blame.jpg")
-	if err != nil {
-		_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-			Content: strPtr(i18n.T(locale, "blame.error.image_load_failed")),
-		})
-		return err
-	}
-	defer imageData.Close()
-
-	// Read image data into memory
-	imageBytes, err := io.ReadAll(imageData)
+	// Render the blame image on the guild's background
+	image, err := loadBlameImage(s, i.GuildID, targetUser, reason)
 	if err != nil {
 		_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 			Content: strPtr(i18n.T(locale, "blame.error.image_load_failed")),
@@ -119,28 +306,18 @@ func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate)
 	}
 
 	// Build the message content
-	content := buildBlameMessage(locale, targetUser, reason)
+	content := blameDescription(i.GuildID, templateIndex, targetUser, interactions.User(i), reason, func() string {
+		return buildBlameMessage(locale, targetUser, reason)
+	})
 
 	// Get target channel from settings
-	targetChannelID := i.ChannelID
-	if i.GuildID != "" {
-		val, _ := settings.GetManager().GetSettingValue(settings.ScopeGuild, i.GuildID, "blame_channel")
-		if channelID, ok := val.(string); ok && channelID != "" {
-			targetChannelID = channelID
-		}
-	}
+	targetChannelID := blameChannel(i)
 
 	// If target channel is different from current channel, we need to send a new message
 	// instead of editing the interaction response (which is tied to the current channel)
 	if targetChannelID != i.ChannelID {
-		_, err = s.ChannelMessageSendComplex(targetChannelID, &discordgo.MessageSend{
-			Files: []*discordgo.File{
-				{
-					Name:        "blame.jpg",
-					ContentType: "image/jpeg",
-					Reader:      bytes.NewReader(imageBytes),
-				},
-			},
+		message, err := s.ChannelMessageSendComplex(targetChannelID, &discordgo.MessageSend{
+			Files: []*discordgo.File{image},
 			Embeds: []*discordgo.MessageEmbed{
 				{
 					Title:       i18n.T(locale, "blame.title"),
@@ -155,6 +332,7 @@ func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate)
 			})
 			return err
 		}
+		recordBlame(s, i, locale, message, targetUser, reason)
 
 		// Inform the user that the message was sent to the configured channel
 		_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
@@ -164,14 +342,8 @@ func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate)
 	}
 
 	// Send the full blame message (with image) to the current channel
-	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-		Files: []*discordgo.File{
-			{
-				Name:        "blame.jpg",
-				ContentType: "image/jpeg",
-				Reader:      bytes.NewReader(imageBytes),
-			},
-		},
+	message, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Files: []*discordgo.File{image},
 		Embeds: &[]*discordgo.MessageEmbed{
 			{
 				Title:       i18n.T(locale, "blame.title"),
@@ -180,8 +352,23 @@ func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate)
 			},
 		},
 	})
+	if err != nil {
+		return err
+	}
+	recordBlame(s, i, locale, message, targetUser, reason)
 
-	return err
+	return nil
+}
+
+// blameChannel returns the configured blame channel, or the current channel when none is set
+func blameChannel(i *discordgo.InteractionCreate) string {
+	if i.GuildID != "" {
+		val, _ := settings.GetManager().GetSettingValue(settings.ScopeGuild, i.GuildID, "blame_channel")
+		if channelID, ok := val.(string); ok && channelID != "" {
+			return channelID
+		}
+	}
+	return i.ChannelID
 }
 
 // buildBlameMessage constructs the blame message
@@ -198,3 +385,8 @@ func buildBlameMessage(locale i18n.SupportedLocale, target *discordgo.User, reas
 func strPtr(s string) *string {
 	return &s
 }
+
+// float64Ptr returns a pointer to a float64
+func float64Ptr(f float64) *float64 {
+	return &f
+}
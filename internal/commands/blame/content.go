@@ -0,0 +1,202 @@
+package blame
+
+import (
+	"bytes"
+	"fmt"
+	"hiei-discord-bot/internal/blames"
+	"hiei-discord-bot/internal/settings"
+	"hiei-discord-bot/resources"
+	"io"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// maxTemplates is the number of templates a guild can configure
+	maxTemplates = 20
+
+	// maxTemplateLength is the longest template accepted, in characters
+	maxTemplateLength = 1000
+)
+
+// Image modes for the blame_image_mode setting
+const (
+	imageModeCustom  = "custom"  // Guild images, the built-in image when there are none
+	imageModeMixed   = "mixed"   // Guild images and the built-in image
+	imageModeDefault = "default" // Built-in image only
+)
+
+// imageExtensions maps the accepted image types to file extensions
+var imageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// parseTemplates splits the blame_templates setting into templates, one per line
+func parseTemplates(raw string) []string {
+	var templates []string
+	for _, line := range strings.Split(raw, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			templates = append(templates, line)
+		}
+	}
+	return templates
+}
+
+// validateTemplates checks the blame_templates setting before it is saved
+func validateTemplates(val interface{}) error {
+	templates := parseTemplates(fmt.Sprintf("%v", val))
+	if len(templates) > maxTemplates {
+		return fmt.Errorf("at most %d templates are allowed", maxTemplates)
+	}
+	for _, template := range templates {
+		if utf8.RuneCountInString(template) > maxTemplateLength {
+			return fmt.Errorf("templates must be at most %d characters", maxTemplateLength)
+		}
+	}
+	return nil
+}
+
+// guildTemplates returns the blame templates configured for a guild
+func guildTemplates(guildID string) []string {
+	if guildID == "" {
+		return nil
+	}
+	val, _ := settings.GetManager().GetSettingValue(settings.ScopeGuild, guildID, "blame_templates")
+	raw, _ := val.(string)
+	return parseTemplates(raw)
+}
+
+// renderTemplate fills in the {target}, {blamer} and {reason} placeholders.
+// A literal \n in a template starts a new line.
+func renderTemplate(template string, target *discordgo.User, blamer *discordgo.User, reason string) string {
+	blamerMention := ""
+	if blamer != nil {
+		blamerMention = blamer.Mention()
+	}
+
+	return strings.NewReplacer(
+		"{target}", target.Mention(),
+		"{blamer}", blamerMention,
+		"{reason}", reason,
+		`\n`, "\n",
+	).Replace(template)
+}
+
+// pickTemplate returns the template at the 1-based index, or a random one when index is 0
+func pickTemplate(templates []string, index int) string {
+	if index > 0 {
+		return templates[index-1]
+	}
+	return templates[rand.Intn(len(templates))]
+}
+
+// blameBackground is the picture a blame image is based on
+type blameBackground struct {
+	Key         string // Identifies the picture in the render cache
+	ContentType string
+	Data        []byte
+}
+
+// loadBlameImage returns the image to attach to a blame: the background picked by the
+// guild's image mode with the target's avatar and the reason drawn on it.
+// The plain background is sent when rendering fails, and animated GIFs are sent as uploaded.
+func loadBlameImage(s *discordgo.Session, guildID string, target *discordgo.User, reason string) (*discordgo.File, error) {
+	background, err := pickBackground(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if background.ContentType != "image/gif" {
+		data, err := renderBlameImage(s.Client, background, target, reason)
+		if err == nil {
+			return &discordgo.File{
+				Name:        "blame.jpg",
+				ContentType: "image/jpeg",
+				Reader:      bytes.NewReader(data),
+			}, nil
+		}
+		slog.Warn("Failed to render blame image, sending it unchanged", "guild_id", guildID, "error", err)
+	}
+
+	return &discordgo.File{
+		Name:        "blame" + imageExtensions[background.ContentType],
+		ContentType: background.ContentType,
+		Reader:      bytes.NewReader(background.Data),
+	}, nil
+}
+
+// pickBackground chooses the blame background according to the guild's image mode
+func pickBackground(guildID string) (*blameBackground, error) {
+	mode := imageModeCustom
+	if guildID != "" {
+		val, _ := settings.GetManager().GetSettingValue(settings.ScopeGuild, guildID, "blame_image_mode")
+		if m, ok := val.(string); ok && m != "" {
+			mode = m
+		}
+	}
+
+	if guildID != "" && mode != imageModeDefault {
+		images, err := blames.GetManager().Images(guildID)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := len(images)
+		if mode == imageModeMixed {
+			// The built-in image is one more candidate
+			candidates++
+		}
+
+		if candidates > 0 {
+			if pick := rand.Intn(candidates); pick < len(images) {
+				image, err := blames.GetManager().Image(guildID, images[pick].ID)
+				if err != nil {
+					return nil, err
+				}
+				return &blameBackground{
+					Key:         fmt.Sprintf("image:%d", image.ID),
+					ContentType: image.ContentType,
+					Data:        image.Data,
+				}, nil
+			}
+		}
+	}
+
+	return loadDefaultBackground()
+}
+
+// loadDefaultBackground returns the built-in blame image
+func loadDefaultBackground() (*blameBackground, error) {
+	imageData, err := resources.Images.Open(resources.ImagesBasePath + "/blame.jpg")
+	if err != nil {
+		return nil, err
+	}
+	defer imageData.Close()
+
+	imageBytes, err := io.ReadAll(imageData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blameBackground{
+		Key:         "default",
+		ContentType: "image/jpeg",
+		Data:        imageBytes,
+	}, nil
+}
+
+// blameDescription renders the blame text with a guild template, or the built-in message without one
+func blameDescription(guildID string, templateIndex int, target *discordgo.User, blamer *discordgo.User, reason string, fallback func() string) string {
+	templates := guildTemplates(guildID)
+	if len(templates) == 0 || templateIndex > len(templates) {
+		return fallback()
+	}
+	return renderTemplate(pickTemplate(templates, templateIndex), target, blamer, reason)
+}
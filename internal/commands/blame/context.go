@@ -1,19 +1,18 @@
 package blame
 
 import (
-	"bytes"
 	"fmt"
 	"hiei-discord-bot/internal/commands"
 	"hiei-discord-bot/internal/i18n"
 	"hiei-discord-bot/internal/interactions"
-	"hiei-discord-bot/resources"
-	"io"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 func init() {
 	commands.Register(NewContext())
+	commands.Register(NewUserContext())
 }
 
 // ContextCommand implements the blame context menu command
@@ -27,9 +26,8 @@ func NewContext() *ContextCommand {
 // Definition returns the context menu command definition
 func (c *ContextCommand) Definition() *discordgo.ApplicationCommand {
 	return &discordgo.ApplicationCommand{
-		Name:    "Blame",
-		Type:    discordgo.MessageApplicationCommand,
-		Version: "0.0.1",
+		Name: "Blame",
+		Type: discordgo.MessageApplicationCommand,
 	}
 }
 
@@ -38,6 +36,11 @@ func (c *ContextCommand) Version() string {
 	return "0.0.1"
 }
 
+// Describe returns the help metadata
+func (c *ContextCommand) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryFun}
+}
+
 // Execute runs the context menu command
 func (c *ContextCommand) Execute(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	locale := i18n.GetUserLocaleFromInteraction(i)
@@ -59,11 +62,67 @@ func (c *ContextCommand) Execute(s *discordgo.Session, i *discordgo.InteractionC
 	if targetMessage == nil || targetMessage.Author == nil {
 		return interactions.RespondError(s, i, locale, "blame.error.no_target", true)
 	}
+	if key := blameRefusal(s, i, targetMessage.Author); key != "" {
+		return interactions.RespondError(s, i, locale, key, true, targetMessage.Author.Username)
+	}
+
 	// Show modal to get the reason
+	return showReasonModal(s, i, locale, targetMessage.ID, targetMessage.Author.ID)
+}
+
+// UserContextCommand implements the blame context menu command on a member's profile
+type UserContextCommand struct{}
+
+// NewUserContext creates a new user blame context menu command instance
+func NewUserContext() *UserContextCommand {
+	return &UserContextCommand{}
+}
+
+// Definition returns the user context menu command definition
+func (c *UserContextCommand) Definition() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name: "Blame",
+		Type: discordgo.UserApplicationCommand,
+	}
+}
+
+// Version returns the command version
+func (c *UserContextCommand) Version() string {
+	return "0.0.1"
+}
+
+// Describe returns the help metadata
+func (c *UserContextCommand) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryFun}
+}
+
+// Execute runs the user context menu command
+func (c *UserContextCommand) Execute(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+
+	data := i.ApplicationCommandData()
+	var targetUser *discordgo.User
+	if data.Resolved != nil {
+		targetUser = data.Resolved.Users[data.TargetID]
+	}
+	if targetUser == nil {
+		return interactions.RespondError(s, i, locale, "blame.error.no_target", true)
+	}
+	if key := blameRefusal(s, i, targetUser); key != "" {
+		return interactions.RespondError(s, i, locale, key, true, targetUser.Username)
+	}
+
+	// There is no message to reply to, so the message ID is left empty
+	return showReasonModal(s, i, locale, "", targetUser.ID)
+}
+
+// showReasonModal asks for the blame reason.
+// CustomID: blame_modal_{messageID}_{targetUserID}, messageID is empty for blames from a profile
+func showReasonModal(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, messageID, targetUserID string) error {
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
-			CustomID: fmt.Sprintf("blame_modal_%s_%s", targetMessage.ID, targetMessage.Author.ID),
+			CustomID: fmt.Sprintf("blame_modal_%s_%s", messageID, targetUserID),
 			Title:    i18n.T(locale, "blame.modal.title"),
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
@@ -89,13 +148,12 @@ func HandleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) err
 	locale := i18n.GetUserLocaleFromInteraction(i)
 
 	// Parse CustomID: blame_modal_{messageID}_{targetUserID}
-	customID := i.ModalSubmitData().CustomID
-	var messageID, targetUserID string
-	fmt.Sscanf(customID, "blame_modal_%s_%s", &messageID, &targetUserID)
-
-	if messageID == "" || targetUserID == "" {
+	ids := strings.TrimPrefix(i.ModalSubmitData().CustomID, "blame_modal_")
+	separator := strings.LastIndex(ids, "_")
+	if separator < 0 || separator == len(ids)-1 {
 		return interactions.RespondError(s, i, locale, "blame.error.invalid_data", true)
 	}
+	messageID, targetUserID := ids[:separator], ids[separator+1:]
 
 	// Get the reason from modal
 	data := i.ModalSubmitData()
@@ -119,14 +177,12 @@ func HandleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) err
 	if err != nil {
 		return interactions.RespondError(s, i, locale, "blame.error.no_target", true)
 	}
+	if key := blameRefusal(s, i, targetUser); key != "" {
+		return interactions.RespondError(s, i, locale, key, true, targetUser.Username)
+	}
 
 	// Get the user who issued the blame
-	var blamer *discordgo.User
-	if i.Member != nil && i.Member.User != nil {
-		blamer = i.Member.User
-	} else if i.User != nil {
-		blamer = i.User
-	}
+	blamer := interactions.User(i)
 
 	// Defer response
 	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -139,17 +195,8 @@ func HandleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) err
 		return err
 	}
 
-	// Read the blame image
-	imageData, err := resources.Images.Open(resources.ImagesBasePath + "/blame.jpg")
-	if err != nil {
-		_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-			Content: strPtr(i18n.T(locale, "blame.error.image_load_failed")),
-		})
-		return err
-	}
-	defer imageData.Close()
-
-	imageBytes, err := io.ReadAll(imageData)
+	// Render the blame image on the guild's background
+	image, err := loadBlameImage(s, i.GuildID, targetUser, reason)
 	if err != nil {
 		_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 			Content: strPtr(i18n.T(locale, "blame.error.image_load_failed")),
@@ -158,23 +205,24 @@ func HandleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) err
 	}
 
 	// Build the message content
-	content := buildBlameMessageWithBlamer(locale, targetUser, reason, blamer)
+	content := blameDescription(i.GuildID, 0, targetUser, blamer, reason, func() string {
+		return buildBlameMessageWithBlamer(locale, targetUser, reason, blamer)
+	})
 
-	// Delete the deferred response
-	err = s.InteractionResponseDelete(i.Interaction)
-	if err != nil {
-		return err
+	// A blame on a message replies to it; a blame from a profile goes to the blame channel
+	channelID := i.ChannelID
+	var reference *discordgo.MessageReference
+	if messageID != "" {
+		reference = &discordgo.MessageReference{
+			MessageID: messageID,
+			ChannelID: i.ChannelID,
+		}
+	} else {
+		channelID = blameChannel(i)
 	}
 
-	// Send the blame message as a reply to the target message
-	_, err = s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
-		Files: []*discordgo.File{
-			{
-				Name:        "blame.jpg",
-				ContentType: "image/jpeg",
-				Reader:      bytes.NewReader(imageBytes),
-			},
-		},
+	message, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Files: []*discordgo.File{image},
 		Embeds: []*discordgo.MessageEmbed{
 			{
 				Title:       i18n.T(locale, "blame.title"),
@@ -182,13 +230,24 @@ func HandleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) err
 				Color:       0xFF0000, // Red color for condemnation
 			},
 		},
-		Reference: &discordgo.MessageReference{
-			MessageID: messageID,
-			ChannelID: i.ChannelID,
-		},
+		Reference: reference,
 	})
+	if err != nil {
+		_, _ = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: strPtr(i18n.T(locale, "command.execution_error")),
+		})
+		return err
+	}
+	recordBlame(s, i, locale, message, targetUser, reason)
 
-	return err
+	// Tell the user where the blame went, or remove the deferred response when it is right here
+	if channelID != i.ChannelID {
+		_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: strPtr(i18n.T(locale, "common.success_prefix") + " " + i18n.T(locale, "blame.success_sent")),
+		})
+		return err
+	}
+	return s.InteractionResponseDelete(i.Interaction)
 }
 
 // buildBlameMessageWithBlamer constructs the blame message with blamer info
@@ -0,0 +1,166 @@
+package blame
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"hiei-discord-bot/internal/blames"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+	"hiei-discord-bot/internal/models"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// leaderboardPeriods maps the leaderboard period choices to their time windows
+var leaderboardPeriods = map[string]time.Duration{
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+	"all": 0,
+}
+
+// recordBlame stores a posted blame in the blame history and puts it to a vote
+// when the guild has voting enabled. Failures are logged only, the blame itself
+// was already posted.
+func recordBlame(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, message *discordgo.Message, target *discordgo.User, reason string) {
+	blame := models.Blame{
+		GuildID:  i.GuildID,
+		TargetID: target.ID,
+		Reason:   reason,
+	}
+	if blamer := interactions.User(i); blamer != nil {
+		blame.BlamerID = blamer.ID
+	}
+	if message != nil {
+		blame.ChannelID = message.ChannelID
+		blame.MessageID = message.ID
+	}
+
+	id, err := blames.GetManager().Record(blame)
+	if err != nil {
+		slog.Error("Failed to record blame", "guild_id", i.GuildID, "target_id", target.ID, "error", err)
+		return
+	}
+	startBlameVote(s, locale, message, id, i.GuildID, target.ID, blame.BlamerID)
+}
+
+// handleStats shows the blames a user received and issued in this guild
+func handleStats(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	user := interactions.User(i)
+	for _, opt := range options {
+		if opt.Name == "user" {
+			user = opt.UserValue(s)
+		}
+	}
+	if user == nil {
+		return interactions.RespondError(s, i, locale, "blame.error.no_target", true)
+	}
+
+	stats, err := blames.GetManager().UserStats(i.GuildID, user.ID)
+	if err != nil {
+		return err
+	}
+
+	reasons := i18n.T(locale, "blame.stats.no_reasons")
+	if len(stats.TopReasons) > 0 {
+		var builder strings.Builder
+		for idx, reason := range stats.TopReasons {
+			builder.WriteString(i18n.Tf(locale, "blame.stats.reason_item", idx+1, reason.Reason, reason.Count))
+			builder.WriteString("\n")
+		}
+		reasons = builder.String()
+	}
+
+	return interactions.RespondCustom(s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title: i18n.Tf(locale, "blame.stats.title", user.Username),
+				Color: 0xFF0000,
+				Thumbnail: &discordgo.MessageEmbedThumbnail{
+					URL: user.AvatarURL("128"),
+				},
+				Fields: []*discordgo.MessageEmbedField{
+					{
+						Name:   i18n.T(locale, "blame.stats.received"),
+						Value:  fmt.Sprintf("%d", stats.Received),
+						Inline: true,
+					},
+					{
+						Name:   i18n.T(locale, "blame.stats.issued"),
+						Value:  fmt.Sprintf("%d", stats.Issued),
+						Inline: true,
+					},
+					{
+						Name:  i18n.T(locale, "blame.stats.top_reasons"),
+						Value: truncate(reasons, 1024),
+					},
+				},
+			},
+		},
+	})
+}
+
+// handleLeaderboard shows the most blamed members of this guild over a time window
+func handleLeaderboard(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	period := "30d"
+	for _, opt := range options {
+		if opt.Name == "period" {
+			period = opt.StringValue()
+		}
+	}
+
+	window, exists := leaderboardPeriods[period]
+	if !exists {
+		period, window = "30d", leaderboardPeriods["30d"]
+	}
+
+	var since time.Time
+	if window > 0 {
+		since = time.Now().Add(-window)
+	}
+
+	leaders, err := blames.GetManager().Leaderboard(i.GuildID, since)
+	if err != nil {
+		return err
+	}
+
+	description := i18n.T(locale, "blame.leaderboard.empty")
+	if len(leaders) > 0 {
+		medals := []string{"🥇", "🥈", "🥉"}
+		var builder strings.Builder
+		for idx, leader := range leaders {
+			rank := fmt.Sprintf("`%2d.`", idx+1)
+			if idx < len(medals) {
+				rank = medals[idx]
+			}
+			builder.WriteString(i18n.Tf(locale, "blame.leaderboard.item", rank, leader.UserID, leader.Count))
+			builder.WriteString("\n")
+		}
+		description = builder.String()
+	}
+
+	return interactions.RespondCustom(s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       i18n.Tf(locale, "blame.leaderboard.title", i18n.T(locale, "blame.leaderboard.period."+period)),
+				Description: description,
+				Color:       0xFF0000,
+			},
+		},
+		// Mentions are for display only
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{},
+		},
+	})
+}
+
+// truncate shortens text to at most max runes
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
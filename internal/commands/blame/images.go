@@ -0,0 +1,240 @@
+package blame
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hiei-discord-bot/internal/blames"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+	"hiei-discord-bot/internal/models"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// maxImageNameLength is the longest image name accepted
+	maxImageNameLength = 50
+
+	// maxImageDimension is the largest width or height accepted. The file size
+	// limit alone does not bound the memory needed to decode an image.
+	maxImageDimension = 4096
+)
+
+var errImageDimensions = errors.New("image dimensions too large")
+
+// handleImage dispatches the image subcommand group, which requires Manage Server
+func handleImage(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	if i.GuildID == "" || i.Member == nil {
+		return interactions.RespondError(s, i, locale, "blame.error.guild_only", true)
+	}
+	if i.Member.Permissions&discordgo.PermissionManageGuild == 0 {
+		return interactions.RespondError(s, i, locale, "blame.error.no_permission", true)
+	}
+	if len(options) == 0 {
+		return nil
+	}
+
+	subcommand := options[0]
+	switch subcommand.Name {
+	case "add":
+		return handleImageAdd(s, i, locale, subcommand.Options)
+	case "list":
+		return handleImageList(s, i, locale)
+	case "remove":
+		return handleImageRemove(s, i, locale, subcommand.Options)
+	}
+
+	return interactions.RespondError(s, i, locale, "command.unknown", true)
+}
+
+// handleImageAdd downloads an attachment and stores it as a blame image
+func handleImageAdd(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	var attachment *discordgo.MessageAttachment
+	var name string
+	for _, opt := range options {
+		switch opt.Name {
+		case "file":
+			if id, ok := opt.Value.(string); ok {
+				if resolved := i.ApplicationCommandData().Resolved; resolved != nil {
+					attachment = resolved.Attachments[id]
+				}
+			}
+		case "name":
+			name = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	if attachment == nil {
+		return interactions.RespondError(s, i, locale, "blame.image.error.no_file", true)
+	}
+	if attachment.Size > blames.MaxImageSize {
+		return interactions.RespondError(s, i, locale, "blame.image.error.too_large", true, blames.MaxImageSize>>20)
+	}
+	if name == "" {
+		name = attachment.Filename
+	}
+	if runes := []rune(name); len(runes) > maxImageNameLength {
+		name = string(runes[:maxImageNameLength])
+	}
+
+	// Downloading may take a while
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := downloadImage(s.Client, attachment.URL)
+	if errors.Is(err, blames.ErrImageTooLarge) {
+		return editImageReply(s, i, locale, "blame.image.error.too_large", false, blames.MaxImageSize>>20)
+	}
+	if err != nil {
+		return editImageReply(s, i, locale, "blame.image.error.download_failed", false)
+	}
+
+	// Trust the content, not the file name
+	contentType := http.DetectContentType(data)
+	if _, ok := imageExtensions[contentType]; !ok {
+		return editImageReply(s, i, locale, "blame.image.error.unsupported_type", false)
+	}
+	if err := checkImageDimensions(data); errors.Is(err, errImageDimensions) {
+		return editImageReply(s, i, locale, "blame.image.error.too_many_pixels", false, maxImageDimension, maxImageDimension)
+	} else if err != nil {
+		return editImageReply(s, i, locale, "blame.image.error.unsupported_type", false)
+	}
+
+	id, err := blames.GetManager().AddImage(models.BlameImage{
+		GuildID:     i.GuildID,
+		Name:        name,
+		ContentType: contentType,
+		Data:        data,
+		UploaderID:  interactions.UserID(i),
+	})
+	switch {
+	case errors.Is(err, blames.ErrImageTooLarge):
+		return editImageReply(s, i, locale, "blame.image.error.too_large", false, blames.MaxImageSize>>20)
+	case errors.Is(err, blames.ErrImageLimit):
+		return editImageReply(s, i, locale, "blame.image.error.limit", false, blames.MaxImagesPerGuild)
+	case err != nil:
+		_ = editImageReply(s, i, locale, "command.execution_error", false)
+		return err
+	}
+
+	return editImageReply(s, i, locale, "blame.image.added", true, name, id)
+}
+
+// handleImageList shows the uploaded blame images of the guild
+func handleImageList(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale) error {
+	images, err := blames.GetManager().Images(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	description := i18n.T(locale, "blame.image.empty")
+	if len(images) > 0 {
+		var builder strings.Builder
+		for _, image := range images {
+			builder.WriteString(i18n.Tf(locale, "blame.image.item",
+				image.ID,
+				image.Name,
+				fmt.Sprintf("%.1f", float64(image.Size)/(1<<20)),
+				image.UploaderID,
+				image.CreatedAt.Unix(),
+			))
+			builder.WriteString("\n")
+		}
+		description = builder.String()
+	}
+
+	return interactions.RespondCustom(s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       i18n.Tf(locale, "blame.image.list_title", len(images), blames.MaxImagesPerGuild),
+				Description: truncate(description, 4096),
+				Color:       0xFF0000,
+			},
+		},
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// handleImageRemove deletes an uploaded blame image
+func handleImageRemove(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	var id int64
+	for _, opt := range options {
+		if opt.Name == "id" {
+			id = opt.IntValue()
+		}
+	}
+
+	err := blames.GetManager().RemoveImage(i.GuildID, id)
+	if errors.Is(err, blames.ErrImageNotFound) {
+		return interactions.RespondError(s, i, locale, "blame.image.error.not_found", true, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	return interactions.RespondSuccess(s, i, locale, "blame.image.removed", true, id)
+}
+
+// downloadImage fetches an attachment, reading at most MaxImageSize bytes
+func downloadImage(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, blames.MaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > blames.MaxImageSize {
+		return nil, blames.ErrImageTooLarge
+	}
+	return data, nil
+}
+
+// checkImageDimensions reads the image header and rejects images larger than
+// maxImageDimension in either direction, without decoding the pixels
+func checkImageDimensions(data []byte) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if config.Width > maxImageDimension || config.Height > maxImageDimension {
+		return errImageDimensions
+	}
+	return nil
+}
+
+// editImageReply replaces the deferred reply with a success or error message
+func editImageReply(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, messageKey string, success bool, args ...interface{}) error {
+	prefix := i18n.T(locale, "common.error_prefix")
+	if success {
+		prefix = i18n.T(locale, "common.success_prefix")
+	}
+
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: strPtr(prefix + " " + i18n.Tf(locale, messageKey, args...)),
+	})
+	return err
+}
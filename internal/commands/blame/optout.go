@@ -0,0 +1,57 @@
+package blame
+
+import (
+	"hiei-discord-bot/internal/interactions"
+	"hiei-discord-bot/internal/settings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Options for the blame_allow user setting. Discord does not expose friend
+// lists to bots, so sharing a server role stands in for knowing each other.
+const (
+	allowEveryone   = "everyone"
+	allowSharedRole = "shared_role" // Only members who share a role with the target
+	allowNobody     = "nobody"
+)
+
+// blameRefusal returns the i18n key explaining why the interaction's user may
+// not blame target, or "" when the blame is allowed. Everyone may blame themselves.
+func blameRefusal(s *discordgo.Session, i *discordgo.InteractionCreate, target *discordgo.User) string {
+	blamer := interactions.User(i)
+	if blamer == nil || blamer.ID == target.ID {
+		return ""
+	}
+
+	val, _ := settings.GetManager().GetSettingValue(settings.ScopeUser, target.ID, "blame_allow")
+	switch val {
+	case allowNobody:
+		return "blame.error.opted_out"
+	case allowSharedRole:
+		if i.GuildID == "" || i.Member == nil || !sharesRole(s, i.GuildID, i.Member, target.ID) {
+			return "blame.error.shared_role_only"
+		}
+	}
+	return ""
+}
+
+// sharesRole reports whether member and the user share at least one role in the guild
+func sharesRole(s *discordgo.Session, guildID string, member *discordgo.Member, userID string) bool {
+	target, err := s.State.Member(guildID, userID)
+	if err != nil {
+		if target, err = s.GuildMember(guildID, userID); err != nil {
+			return false
+		}
+	}
+
+	roles := make(map[string]bool, len(member.Roles))
+	for _, id := range member.Roles {
+		roles[id] = true
+	}
+	for _, id := range target.Roles {
+		if roles[id] {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package blame
 
 import (
 	"hiei-discord-bot/internal/interactions"
+	"hiei-discord-bot/internal/scheduler"
 )
 
 func init() {
@@ -9,4 +10,12 @@ func init() {
 
 	// Register modal handler for blame reason input
 	router.RegisterModal("blame_modal_", HandleModalSubmit)
+
+	// Register vote and appeal handlers
+	router.RegisterComponent(voteButtonPrefix, HandleVoteButton)
+	router.RegisterComponent(appealButtonPrefix, HandleAppealButton)
+	router.RegisterModal(appealModalPrefix, HandleAppealModal)
+
+	// Reschedule votes that were open when the bot stopped
+	scheduler.Get().OnStart(restoreOpenVotes)
 }
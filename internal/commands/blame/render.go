@@ -0,0 +1,333 @@
+package blame
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"unicode"
+
+	"hiei-discord-bot/resources"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// renderWidth is the width of generated blame images
+	renderWidth = 800
+
+	// renderMinHeight and renderMaxHeight bound the height of generated blame images
+	renderMinHeight = 400
+	renderMaxHeight = 1000
+
+	// maxTextSize and minTextSize bound the font size of the reason in pixels,
+	// which shrinks by textSizeStep until the text fits
+	maxTextSize  = 72
+	minTextSize  = 16
+	textSizeStep = 4
+
+	// blameFontFile is the CJK font preferring traditional Chinese glyphs
+	blameFontFile = "NotoSansCJKtc-Bold-Subset.otf"
+
+	// renderCacheSize is the number of generated images kept in memory
+	renderCacheSize = 64
+)
+
+// blameFont is parsed on first use. Faces of it are created per image, since a
+// face is not safe for concurrent use but the font itself is.
+var (
+	blameFont     *sfnt.Font
+	blameFontErr  error
+	blameFontOnce sync.Once
+)
+
+// loadBlameFont parses the embedded font once
+func loadBlameFont() (*sfnt.Font, error) {
+	blameFontOnce.Do(func() {
+		data, err := resources.Fonts.ReadFile(path.Join(resources.FontsBasePath, blameFontFile))
+		if err != nil {
+			blameFontErr = err
+			return
+		}
+		blameFont, blameFontErr = opentype.Parse(data)
+	})
+	return blameFont, blameFontErr
+}
+
+// renderCache keeps recently generated images, evicting the oldest first
+type renderCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	order   []string
+}
+
+var cache = &renderCache{entries: make(map[string][]byte)}
+
+func (c *renderCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.entries[key]
+	return data, ok
+}
+
+func (c *renderCache) put(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+	if len(c.order) >= renderCacheSize {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.entries[key] = data
+	c.order = append(c.order, key)
+}
+
+// renderKey identifies a generated image by its background, target avatar and reason
+func renderKey(backgroundKey string, target *discordgo.User, reason string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{backgroundKey, target.ID, target.Avatar, reason}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// renderBlameImage composites the target's avatar and the reason onto the background as a JPEG
+func renderBlameImage(client *http.Client, background *blameBackground, target *discordgo.User, reason string) ([]byte, error) {
+	key := renderKey(background.Key, target, reason)
+	if data, ok := cache.get(key); ok {
+		return data, nil
+	}
+
+	// Decoding allocates every pixel, so the size is checked again rather than
+	// trusting that the stored image passed the upload checks
+	if err := checkImageDimensions(background.Data); err != nil {
+		return nil, fmt.Errorf("check background: %w", err)
+	}
+	base, _, err := image.Decode(bytes.NewReader(background.Data))
+	if err != nil {
+		return nil, fmt.Errorf("decode background: %w", err)
+	}
+	avatar, err := fetchAvatar(client, target)
+	if err != nil {
+		return nil, fmt.Errorf("fetch avatar: %w", err)
+	}
+
+	canvas, err := composeBlameImage(base, avatar, reason)
+	if err != nil {
+		return nil, fmt.Errorf("compose image: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, canvas, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, err
+	}
+
+	data := buf.Bytes()
+	cache.put(key, data)
+	return data, nil
+}
+
+// fetchAvatar downloads and decodes the target's avatar, with the same limits as uploaded images
+func fetchAvatar(client *http.Client, target *discordgo.User) (image.Image, error) {
+	data, err := downloadImage(client, target.AvatarURL("256"))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkImageDimensions(data); err != nil {
+		return nil, err
+	}
+
+	avatar, _, err := image.Decode(bytes.NewReader(data))
+	return avatar, err
+}
+
+// composeBlameImage draws the background, a translucent band, the round avatar and the reason
+func composeBlameImage(base image.Image, avatar image.Image, reason string) (*image.RGBA, error) {
+	bounds := base.Bounds()
+	height := bounds.Dy() * renderWidth / max(bounds.Dx(), 1)
+	height = min(max(height, renderMinHeight), renderMaxHeight)
+
+	canvas := image.NewRGBA(image.Rect(0, 0, renderWidth, height))
+	drawCover(canvas, base)
+
+	// Bottom band holding the avatar and the reason
+	bandHeight := height * 32 / 100
+	band := image.Rect(0, height-bandHeight, renderWidth, height)
+	draw.Draw(canvas, band, image.NewUniform(color.NRGBA{A: 170}), image.Point{}, draw.Over)
+
+	padding := bandHeight / 10
+	avatarSize := bandHeight - 2*padding
+	avatarRect := image.Rect(padding, band.Min.Y+padding, padding+avatarSize, band.Min.Y+padding+avatarSize)
+	scaled := image.NewRGBA(image.Rect(0, 0, avatarSize, avatarSize))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), avatar, avatar.Bounds(), draw.Src, nil)
+	draw.DrawMask(canvas, avatarRect, scaled, image.Point{}, &circleMask{size: avatarSize}, image.Point{}, draw.Over)
+
+	textRect := image.Rect(avatarRect.Max.X+2*padding, avatarRect.Min.Y, renderWidth-2*padding, avatarRect.Max.Y)
+	if err := drawFittedText(canvas, textRect, reason); err != nil {
+		return nil, err
+	}
+
+	return canvas, nil
+}
+
+// drawCover scales src to cover dst, cropping the overflow around the center
+func drawCover(dst *image.RGBA, src image.Image) {
+	sb := src.Bounds()
+	db := dst.Bounds()
+
+	// Compare aspect ratios to find the source region with the destination's ratio
+	crop := sb
+	if sb.Dx()*db.Dy() > sb.Dy()*db.Dx() {
+		width := sb.Dy() * db.Dx() / db.Dy()
+		crop.Min.X = sb.Min.X + (sb.Dx()-width)/2
+		crop.Max.X = crop.Min.X + width
+	} else {
+		height := sb.Dx() * db.Dy() / db.Dx()
+		crop.Min.Y = sb.Min.Y + (sb.Dy()-height)/2
+		crop.Max.Y = crop.Min.Y + height
+	}
+
+	draw.CatmullRom.Scale(dst, db, src, crop, draw.Src, nil)
+}
+
+// drawFittedText draws text wrapped into rect at the largest font size that fits.
+// At the smallest size, lines that do not fit are cut off with an ellipsis.
+func drawFittedText(dst *image.RGBA, rect image.Rectangle, text string) error {
+	typeface, err := loadBlameFont()
+	if err != nil {
+		return fmt.Errorf("load font: %w", err)
+	}
+
+	var face font.Face
+	var lines []string
+	for size := maxTextSize; ; size -= textSizeStep {
+		if face != nil {
+			face.Close()
+		}
+		face, err = opentype.NewFace(typeface, &opentype.FaceOptions{
+			Size:    float64(size),
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			return err
+		}
+
+		lines = wrapText(face, text, rect.Dx())
+		if len(lines)*face.Metrics().Height.Ceil() <= rect.Dy() || size-textSizeStep < minTextSize {
+			break
+		}
+	}
+	defer face.Close()
+
+	lineHeight := face.Metrics().Height.Ceil()
+	if maxLines := max(rect.Dy()/lineHeight, 1); len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] = fitWithEllipsis(face, lines[maxLines-1], rect.Dx())
+	}
+
+	// Center the lines vertically, each with a drop shadow
+	shadow := max(lineHeight/24, 1)
+	top := rect.Min.Y + (rect.Dy()-len(lines)*lineHeight)/2 + face.Metrics().Ascent.Ceil()
+	for idx, line := range lines {
+		y := top + idx*lineHeight
+		drawString(dst, face, line, rect.Min.X+shadow, y+shadow, color.Black)
+		drawString(dst, face, line, rect.Min.X, y, color.White)
+	}
+	return nil
+}
+
+// drawString draws a single line of text with its baseline at y
+func drawString(dst *image.RGBA, face font.Face, text string, x, y int, c color.Color) {
+	drawer := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
+
+// wrapText breaks text into lines no wider than width pixels.
+// Lines break at spaces where possible, anywhere otherwise, so CJK text wraps per character.
+func wrapText(face font.Face, text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		var line []rune
+		for _, r := range paragraph {
+			if unicode.IsSpace(r) && len(line) == 0 {
+				continue
+			}
+			line = append(line, r)
+			if measure(face, string(line)) <= width {
+				continue
+			}
+
+			// Move the overflowing word to the next line, or just the last rune if there is no space
+			cut := len(line) - 1
+			if !unicode.IsSpace(r) {
+				for idx := len(line) - 1; idx > 0; idx-- {
+					if unicode.IsSpace(line[idx]) {
+						cut = idx + 1
+						break
+					}
+				}
+			}
+			if cut == 0 {
+				cut = 1
+			}
+			lines = append(lines, strings.TrimRightFunc(string(line[:cut]), unicode.IsSpace))
+			line = []rune(strings.TrimLeftFunc(string(line[cut:]), unicode.IsSpace))
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// fitWithEllipsis shortens a line so that it fits width pixels including a trailing ellipsis
+func fitWithEllipsis(face font.Face, line string, width int) string {
+	runes := []rune(line)
+	for len(runes) > 0 && measure(face, string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// measure returns the width of text in pixels
+func measure(face font.Face, text string) int {
+	return font.MeasureString(face, text).Ceil()
+}
+
+// circleMask is an alpha mask of a circle filling a size×size square
+type circleMask struct {
+	size int
+}
+
+func (m *circleMask) ColorModel() color.Model {
+	return color.AlphaModel
+}
+
+func (m *circleMask) Bounds() image.Rectangle {
+	return image.Rect(0, 0, m.size, m.size)
+}
+
+func (m *circleMask) At(x, y int) color.Color {
+	r := float64(m.size) / 2
+	dx := float64(x) + 0.5 - r
+	dy := float64(y) + 0.5 - r
+	if dx*dx+dy*dy <= r*r {
+		return color.Alpha{A: 255}
+	}
+	return color.Alpha{}
+}
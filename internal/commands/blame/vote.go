@@ -0,0 +1,376 @@
+package blame
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"hiei-discord-bot/internal/blames"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+	"hiei-discord-bot/internal/models"
+	"hiei-discord-bot/internal/scheduler"
+	"hiei-discord-bot/internal/settings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	voteButtonPrefix   = "blame_vote_"
+	appealButtonPrefix = "blame_appeal_"
+	appealModalPrefix  = "blame_appeal_"
+
+	// maxVoteThreshold and maxVoteMinutes bound the vote settings
+	maxVoteThreshold = 25
+	maxVoteMinutes   = 7 * 24 * 60
+)
+
+// Embed colors for the states of a voted blame
+const (
+	colorPending   = 0xE67E22
+	colorConfirmed = 0xFF0000
+	colorDismissed = 0x95A5A6
+)
+
+// voteSettings returns whether blames in a guild go to a vote, the net agree
+// votes needed and the time limit
+func voteSettings(guildID string) (bool, int, time.Duration) {
+	if guildID == "" {
+		return false, 0, 0
+	}
+
+	mgr := settings.GetManager()
+	enabledVal, _ := mgr.GetSettingValue(settings.ScopeGuild, guildID, "blame_vote")
+	thresholdVal, _ := mgr.GetSettingValue(settings.ScopeGuild, guildID, "blame_vote_threshold")
+	minutesVal, _ := mgr.GetSettingValue(settings.ScopeGuild, guildID, "blame_vote_minutes")
+
+	enabled, _ := enabledVal.(bool)
+	threshold, ok := thresholdVal.(int)
+	if !ok || threshold < 1 {
+		threshold = 3
+	}
+	minutes, ok := minutesVal.(int)
+	if !ok || minutes < 1 {
+		minutes = 60
+	}
+	return enabled, min(threshold, maxVoteThreshold), time.Duration(min(minutes, maxVoteMinutes)) * time.Minute
+}
+
+// startBlameVote puts a posted blame to a vote when the guild has voting enabled
+func startBlameVote(s *discordgo.Session, locale i18n.SupportedLocale, message *discordgo.Message, blameID int64, guildID, targetID, blamerID string) {
+	enabled, threshold, duration := voteSettings(guildID)
+	if !enabled || message == nil || blameID == 0 {
+		return
+	}
+
+	vote := &models.BlameVote{
+		ID:        blames.NewVoteID(),
+		BlameID:   blameID,
+		Kind:      blames.VoteKindBlame,
+		GuildID:   guildID,
+		ChannelID: message.ChannelID,
+		MessageID: message.ID,
+		TargetID:  targetID,
+		BlamerID:  blamerID,
+		Threshold: threshold,
+		Locale:    string(locale),
+		EndsAt:    time.Now().Add(duration).Truncate(time.Second),
+	}
+	if err := blames.GetManager().StartVote(vote); err != nil {
+		slog.Error("Failed to start blame vote", "blame_id", blameID, "error", err)
+		return
+	}
+
+	if err := editVoteMessage(s, vote, blames.Tally{}); err != nil {
+		slog.Error("Failed to add blame vote to message", "vote_id", vote.ID, "error", err)
+	}
+	scheduleVoteClose(vote.ID, vote.EndsAt)
+}
+
+// HandleVoteButton records an Agree or Disagree ballot
+func HandleVoteButton(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+
+	// Parse CustomID: blame_vote_{voteID}_{agree|disagree}
+	parts := strings.Split(strings.TrimPrefix(i.MessageComponentData().CustomID, voteButtonPrefix), "_")
+	if len(parts) != 2 {
+		return interactions.RespondError(s, i, locale, "blame.error.invalid_data", true)
+	}
+
+	user := interactions.User(i)
+	if user == nil {
+		return interactions.RespondError(s, i, locale, "blame.error.invalid_data", true)
+	}
+
+	vote, tally, err := blames.GetManager().Cast(parts[0], user.ID, parts[1] == "agree")
+	if err != nil {
+		return respondVoteError(s, i, locale, err)
+	}
+	if vote.Status != blames.VoteOpen {
+		scheduler.Get().Cancel(voteJobID(vote.ID))
+		slog.Info("Blame vote decided", "vote_id", vote.ID, "kind", vote.Kind, "status", vote.Status)
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: voteMessageData(i.Message, vote, tally),
+	})
+}
+
+// HandleAppealButton shows the appeal modal to the blamed user
+func HandleAppealButton(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	voteID := strings.TrimPrefix(i.MessageComponentData().CustomID, appealButtonPrefix)
+
+	vote, _, err := blames.GetManager().Vote(voteID)
+	if err != nil {
+		return respondVoteError(s, i, locale, err)
+	}
+	if user := interactions.User(i); user == nil || user.ID != vote.TargetID {
+		return respondVoteError(s, i, locale, blames.ErrNotTarget)
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: appealModalPrefix + voteID,
+			Title:    i18n.T(locale, "blame.appeal.modal.title"),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "appeal_reason",
+							Label:       i18n.T(locale, "blame.appeal.modal.reason_label"),
+							Style:       discordgo.TextInputParagraph,
+							Placeholder: i18n.T(locale, "blame.appeal.modal.reason_placeholder"),
+							Required:    true,
+							MaxLength:   200,
+							MinLength:   1,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// HandleAppealModal opens the counter-vote with the appeal reason
+func HandleAppealModal(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	data := i.ModalSubmitData()
+	voteID := strings.TrimPrefix(data.CustomID, appealModalPrefix)
+
+	var reason string
+	for _, row := range data.Components {
+		if actionRow, ok := row.(*discordgo.ActionsRow); ok {
+			for _, component := range actionRow.Components {
+				if textInput, ok := component.(*discordgo.TextInput); ok && textInput.CustomID == "appeal_reason" {
+					reason = strings.TrimSpace(textInput.Value)
+				}
+			}
+		}
+	}
+	if reason == "" {
+		return interactions.RespondError(s, i, locale, "blame.error.no_reason", true)
+	}
+
+	user := interactions.User(i)
+	if user == nil {
+		return interactions.RespondError(s, i, locale, "blame.error.invalid_data", true)
+	}
+
+	_, threshold, duration := voteSettings(i.GuildID)
+	appeal, err := blames.GetManager().Appeal(voteID, user.ID, reason, threshold, time.Now().Add(duration).Truncate(time.Second))
+	if err != nil {
+		return respondVoteError(s, i, locale, err)
+	}
+	scheduleVoteClose(appeal.ID, appeal.EndsAt)
+
+	// The modal was opened from the blame message, so it can be updated in place
+	if i.Message != nil {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: voteMessageData(i.Message, appeal, blames.Tally{}),
+		})
+	}
+
+	if err := editVoteMessage(s, appeal, blames.Tally{}); err != nil {
+		slog.Warn("Failed to add appeal to blame message", "vote_id", appeal.ID, "error", err)
+	}
+	return interactions.RespondSuccess(s, i, locale, "blame.appeal.filed", true)
+}
+
+// scheduleVoteClose decides the vote at its time limit
+func scheduleVoteClose(id string, at time.Time) {
+	scheduler.Get().Schedule(voteJobID(id), at, func(s *discordgo.Session) {
+		vote, tally, err := blames.GetManager().Close(id)
+		if errors.Is(err, blames.ErrVoteClosed) {
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to close blame vote", "vote_id", id, "error", err)
+			return
+		}
+
+		slog.Info("Blame vote decided", "vote_id", vote.ID, "kind", vote.Kind, "status", vote.Status)
+		if err := editVoteMessage(s, vote, tally); err != nil {
+			// The message may have been deleted; the outcome is recorded regardless
+			slog.Warn("Failed to update blame vote message", "vote_id", vote.ID, "error", err)
+		}
+	})
+}
+
+func voteJobID(id string) string {
+	return "blame_vote:" + id
+}
+
+// restoreOpenVotes reschedules the votes that were open when the bot stopped.
+// Votes whose time limit passed while the bot was offline are decided immediately.
+func restoreOpenVotes(s *discordgo.Session) {
+	votes, err := blames.GetManager().OpenVotes()
+	if err != nil {
+		slog.Error("Failed to load open blame votes", "error", err)
+		return
+	}
+
+	for _, vote := range votes {
+		scheduleVoteClose(vote.ID, vote.EndsAt)
+	}
+	slog.Info("Restored open blame votes", "count", len(votes))
+}
+
+// editVoteMessage fetches the blame message and edits the vote state into it
+func editVoteMessage(s *discordgo.Session, vote *models.BlameVote, tally blames.Tally) error {
+	message, err := s.ChannelMessage(vote.ChannelID, vote.MessageID)
+	if err != nil {
+		return err
+	}
+
+	data := voteMessageData(message, vote, tally)
+	_, err = s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         vote.MessageID,
+		Channel:    vote.ChannelID,
+		Embeds:     &data.Embeds,
+		Components: &data.Components,
+	})
+	return err
+}
+
+// voteMessageData returns the blame message's embed with the vote's field and
+// the buttons for the vote's state. Attachments are left untouched.
+func voteMessageData(message *discordgo.Message, vote *models.BlameVote, tally blames.Tally) *discordgo.InteractionResponseData {
+	locale := i18n.SupportedLocale(vote.Locale)
+
+	embed := &discordgo.MessageEmbed{Title: i18n.T(locale, "blame.title")}
+	if message != nil && len(message.Embeds) > 0 {
+		copied := *message.Embeds[0]
+		embed = &copied
+	}
+
+	field := voteField(locale, vote, tally)
+	fields := make([]*discordgo.MessageEmbedField, 0, len(embed.Fields)+1)
+	replaced := false
+	for _, f := range embed.Fields {
+		if f.Name == field.Name {
+			f, replaced = field, true
+		}
+		fields = append(fields, f)
+	}
+	if !replaced {
+		fields = append(fields, field)
+	}
+	embed.Fields = fields
+
+	switch {
+	case vote.Status == blames.VoteOpen:
+		embed.Color = colorPending
+	case (vote.Kind == blames.VoteKindBlame) == (vote.Status == blames.VotePassed):
+		embed.Color = colorConfirmed
+	default:
+		embed.Color = colorDismissed
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: voteComponents(locale, vote),
+	}
+}
+
+// voteField renders the tally or outcome of a vote
+func voteField(locale i18n.SupportedLocale, vote *models.BlameVote, tally blames.Tally) *discordgo.MessageEmbedField {
+	key := "blame.vote." + vote.Kind + "." + vote.Status
+	var value string
+	switch {
+	case vote.Kind == blames.VoteKindAppeal && vote.Status == blames.VoteOpen:
+		value = i18n.Tf(locale, key, vote.TargetID, vote.Reason, tally.Agree, tally.Disagree, vote.Threshold, vote.EndsAt.Unix())
+	case vote.Kind == blames.VoteKindAppeal:
+		value = i18n.Tf(locale, key, vote.TargetID, vote.Reason, tally.Agree, tally.Disagree)
+	case vote.Status == blames.VoteOpen:
+		value = i18n.Tf(locale, key, tally.Agree, tally.Disagree, vote.Threshold, vote.EndsAt.Unix())
+	default:
+		value = i18n.Tf(locale, key, tally.Agree, tally.Disagree)
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  i18n.T(locale, "blame.vote."+vote.Kind+".field"),
+		Value: truncate(value, 1024),
+	}
+}
+
+// voteComponents returns the ballot buttons of an open vote, the Appeal button
+// of a confirmed blame, and nothing once the outcome is final
+func voteComponents(locale i18n.SupportedLocale, vote *models.BlameVote) []discordgo.MessageComponent {
+	var buttons []discordgo.MessageComponent
+	switch {
+	case vote.Status == blames.VoteOpen:
+		buttons = []discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    i18n.T(locale, "blame.vote.button.agree"),
+				Style:    discordgo.SuccessButton,
+				CustomID: voteButtonPrefix + vote.ID + "_agree",
+				Emoji:    &discordgo.ComponentEmoji{Name: "👍"},
+			},
+			discordgo.Button{
+				Label:    i18n.T(locale, "blame.vote.button.disagree"),
+				Style:    discordgo.DangerButton,
+				CustomID: voteButtonPrefix + vote.ID + "_disagree",
+				Emoji:    &discordgo.ComponentEmoji{Name: "👎"},
+			},
+		}
+	case vote.Kind == blames.VoteKindBlame && vote.Status == blames.VotePassed:
+		buttons = []discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    i18n.T(locale, "blame.vote.button.appeal"),
+				Style:    discordgo.SecondaryButton,
+				CustomID: appealButtonPrefix + vote.ID,
+				Emoji:    &discordgo.ComponentEmoji{Name: "⚖️"},
+			},
+		}
+	default:
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: buttons},
+	}
+}
+
+// respondVoteError maps vote manager errors to localized messages
+func respondVoteError(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, err error) error {
+	switch {
+	case errors.Is(err, blames.ErrVoteNotFound), errors.Is(err, blames.ErrNotBlameVote):
+		return interactions.RespondError(s, i, locale, "blame.vote.error.not_found", true)
+	case errors.Is(err, blames.ErrVoteClosed):
+		return interactions.RespondError(s, i, locale, "blame.vote.error.closed", true)
+	case errors.Is(err, blames.ErrNotEligible):
+		return interactions.RespondError(s, i, locale, "blame.vote.error.not_eligible", true)
+	case errors.Is(err, blames.ErrNotTarget):
+		return interactions.RespondError(s, i, locale, "blame.vote.error.not_target", true)
+	case errors.Is(err, blames.ErrNotConfirmed):
+		return interactions.RespondError(s, i, locale, "blame.vote.error.not_confirmed", true)
+	case errors.Is(err, blames.ErrAppealExists):
+		return interactions.RespondError(s, i, locale, "blame.vote.error.appeal_exists", true)
+	}
+	return err
+}
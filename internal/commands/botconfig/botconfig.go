@@ -0,0 +1,108 @@
+package botconfig
+
+import (
+	"hiei-discord-bot/internal/commands"
+	"hiei-discord-bot/internal/config"
+	"hiei-discord-bot/internal/hotreload"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxErrorLength keeps validation errors within Discord's 2000 character limit
+const maxErrorLength = 1500
+
+func init() {
+	commands.Register(New())
+}
+
+// Command implements the config slash command
+type Command struct{}
+
+// New creates a new config command instance
+func New() *Command {
+	return &Command{}
+}
+
+// Definition returns the slash command definition
+func (c *Command) Definition() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "config",
+		Description: "Manage the bot configuration (bot owners only)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "reload",
+				Description: "Reload the config file and translations without a restart",
+			},
+		},
+	}
+}
+
+// Version returns the command version
+func (c *Command) Version() string {
+	return "1.0.0"
+}
+
+// Describe returns the help metadata
+func (c *Command) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryAdmin}
+}
+
+// Execute runs the config command
+func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	if !config.Get().IsOwner(interactions.UserID(i)) {
+		return interactions.RespondError(s, i, locale, "command.config.error.owner_only", true)
+	}
+
+	result, err := hotreload.Reload()
+	if err != nil {
+		message := err.Error()
+		if runes := []rune(message); len(runes) > maxErrorLength {
+			message = string(runes[:maxErrorLength]) + "…"
+		}
+		return interactions.RespondError(s, i, locale, "command.config.reload.failed", true, message)
+	}
+
+	return interactions.RespondCustom(s, i, &discordgo.InteractionResponseData{
+		Flags:  discordgo.MessageFlagsEphemeral,
+		Embeds: []*discordgo.MessageEmbed{resultEmbed(locale, result)},
+	})
+}
+
+// resultEmbed lists the keys a reload applied and those that need a restart
+func resultEmbed(locale i18n.SupportedLocale, result hotreload.Result) *discordgo.MessageEmbed {
+	applied := i18n.T(locale, "command.config.reload.no_changes")
+	if len(result.Applied) > 0 {
+		applied = formatKeys(result.Applied)
+	}
+
+	translations := i18n.T(locale, "command.config.reload.embedded")
+	if dir := config.Get().I18nDir; dir != "" {
+		translations = i18n.Tf(locale, "command.config.reload.directory", dir)
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{Name: i18n.T(locale, "command.config.reload.field.applied"), Value: applied},
+		{Name: i18n.T(locale, "command.config.reload.field.translations"), Value: translations},
+	}
+	if len(result.RestartRequired) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(locale, "command.config.reload.field.restart"),
+			Value: formatKeys(result.RestartRequired),
+		})
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:  i18n.T(locale, "command.config.reload.title"),
+		Color:  0x2ECC71,
+		Fields: fields,
+	}
+}
+
+func formatKeys(keys []string) string {
+	return "`" + strings.Join(keys, "`, `") + "`"
+}
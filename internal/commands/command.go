@@ -9,9 +9,57 @@ type Command interface {
 	// Definition returns the slash command definition for Discord registration
 	Definition() *discordgo.ApplicationCommand
 
-	// Version returns the command version (semver)
+	// Version returns the command version (semver). Changed definitions are
+	// re-synced without a bump too, but that is logged as a warning.
 	Version() string
 
 	// Execute runs the command logic when invoked
 	Execute(s *discordgo.Session, i *discordgo.InteractionCreate) error
 }
+
+// Help categories, listed by /help and the command docs in this order
+const (
+	CategoryGeneral = "general"
+	CategoryFun     = "fun"
+	CategoryGames   = "games"
+	CategoryUtility = "utility"
+	CategoryAdmin   = "admin"
+	CategoryOther   = "other"
+)
+
+// Categories lists the known categories in display order
+var Categories = []string{
+	CategoryGeneral,
+	CategoryFun,
+	CategoryGames,
+	CategoryUtility,
+	CategoryAdmin,
+	CategoryOther,
+}
+
+// Metadata describes a command for /help and the generated command docs
+type Metadata struct {
+	// Category groups the command, see the Category constants (default: CategoryOther)
+	Category string
+
+	// DetailsKey is the translation key of a long description
+	// (default: command.help.commands.<key>.details)
+	DetailsKey string
+
+	// ExamplesKey is the translation key of usage examples, one per line
+	// (default: command.help.commands.<key>.examples)
+	ExamplesKey string
+
+	// Permissions needed to run the command, in addition to the definition's
+	// DefaultMemberPermissions, for commands that check permissions themselves
+	Permissions int64
+
+	// Hidden commands are left out of /help and the command docs
+	Hidden bool
+}
+
+// Describer is implemented by commands that provide help metadata.
+// Commands without it are documented from their definition alone.
+type Describer interface {
+	Describe() Metadata
+}
@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// canonicalCommand is the part of a command definition Discord stores, with the
+// defaults Discord fills in applied, so local definitions compare equal to the
+// commands Discord returns for them
+type canonicalCommand struct {
+	Type                     discordgo.ApplicationCommandType `json:"type"`
+	Name                     string                           `json:"name"`
+	NameLocalizations        map[discordgo.Locale]string      `json:"name_localizations,omitempty"`
+	Description              string                           `json:"description"`
+	DescriptionLocalizations map[discordgo.Locale]string      `json:"description_localizations,omitempty"`
+	DefaultMemberPermissions *int64                           `json:"default_member_permissions"`
+	DMPermission             bool                             `json:"dm_permission"`
+	NSFW                     bool                             `json:"nsfw"`
+	Options                  []canonicalOption                `json:"options,omitempty"`
+}
+
+type canonicalOption struct {
+	Type                     discordgo.ApplicationCommandOptionType `json:"type"`
+	Name                     string                                 `json:"name"`
+	NameLocalizations        map[discordgo.Locale]string            `json:"name_localizations,omitempty"`
+	Description              string                                 `json:"description"`
+	DescriptionLocalizations map[discordgo.Locale]string            `json:"description_localizations,omitempty"`
+	Required                 bool                                   `json:"required"`
+	Choices                  []canonicalChoice                      `json:"choices,omitempty"`
+	ChannelTypes             []discordgo.ChannelType                `json:"channel_types,omitempty"`
+	MinValue                 *float64                               `json:"min_value,omitempty"`
+	MaxValue                 float64                                `json:"max_value,omitempty"`
+	MinLength                *int                                   `json:"min_length,omitempty"`
+	MaxLength                int                                    `json:"max_length,omitempty"`
+	Autocomplete             bool                                   `json:"autocomplete"`
+	Options                  []canonicalOption                      `json:"options,omitempty"`
+}
+
+type canonicalChoice struct {
+	Name              string                      `json:"name"`
+	NameLocalizations map[discordgo.Locale]string `json:"name_localizations,omitempty"`
+	Value             string                      `json:"value"`
+}
+
+// canonicalize converts a definition to its canonical form
+func canonicalize(def *discordgo.ApplicationCommand) canonicalCommand {
+	c := canonicalCommand{
+		Type:                     def.Type,
+		Name:                     def.Name,
+		NameLocalizations:        localizations(def.NameLocalizations),
+		Description:              def.Description,
+		DescriptionLocalizations: localizations(def.DescriptionLocalizations),
+		DefaultMemberPermissions: def.DefaultMemberPermissions,
+		DMPermission:             def.DMPermission == nil || *def.DMPermission,
+		NSFW:                     def.NSFW != nil && *def.NSFW,
+		Options:                  canonicalOptions(def.Options),
+	}
+	if c.Type == 0 {
+		c.Type = discordgo.ChatApplicationCommand
+	}
+	return c
+}
+
+// Hash returns a hash of the canonical form of a definition, localizations
+// included, which changes whenever the definition Discord stores would
+func Hash(def *discordgo.ApplicationCommand) string {
+	// Map keys are sorted when marshalled, so equal definitions hash equally
+	data, err := json.Marshal(canonicalize(def))
+	if err != nil {
+		panic(err) // canonical commands only hold strings, numbers and maps of strings
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func canonicalOptions(options []*discordgo.ApplicationCommandOption) []canonicalOption {
+	if len(options) == 0 {
+		return nil
+	}
+
+	result := make([]canonicalOption, 0, len(options))
+	for _, opt := range options {
+		o := canonicalOption{
+			Type:                     opt.Type,
+			Name:                     opt.Name,
+			NameLocalizations:        nonEmpty(opt.NameLocalizations),
+			Description:              opt.Description,
+			DescriptionLocalizations: nonEmpty(opt.DescriptionLocalizations),
+			Required:                 opt.Required,
+			ChannelTypes:             opt.ChannelTypes,
+			MinValue:                 opt.MinValue,
+			MaxValue:                 opt.MaxValue,
+			MinLength:                opt.MinLength,
+			MaxLength:                opt.MaxLength,
+			Autocomplete:             opt.Autocomplete,
+			Options:                  canonicalOptions(opt.Options),
+		}
+		if len(o.ChannelTypes) == 0 {
+			o.ChannelTypes = nil
+		}
+		for _, choice := range opt.Choices {
+			// Discord returns numbers as float64, so compare values by their text
+			o.Choices = append(o.Choices, canonicalChoice{
+				Name:              choice.Name,
+				NameLocalizations: nonEmpty(choice.NameLocalizations),
+				Value:             fmt.Sprint(choice.Value),
+			})
+		}
+		result = append(result, o)
+	}
+	return result
+}
+
+func localizations(l *map[discordgo.Locale]string) map[discordgo.Locale]string {
+	if l == nil {
+		return nil
+	}
+	return nonEmpty(*l)
+}
+
+func nonEmpty(l map[discordgo.Locale]string) map[discordgo.Locale]string {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// diffCommand lists the fields that differ between a local definition and a
+// registered command, e.g. "description" or "options.user.reason.max_length"
+func diffCommand(local, remote *discordgo.ApplicationCommand) []string {
+	l, r := canonicalize(local), canonicalize(remote)
+
+	var fields []string
+	compare := func(name string, a, b interface{}) {
+		if !reflect.DeepEqual(a, b) {
+			fields = append(fields, name)
+		}
+	}
+	compare("description", l.Description, r.Description)
+	compare("name_localizations", l.NameLocalizations, r.NameLocalizations)
+	compare("description_localizations", l.DescriptionLocalizations, r.DescriptionLocalizations)
+	compare("default_member_permissions", l.DefaultMemberPermissions, r.DefaultMemberPermissions)
+	compare("dm_permission", l.DMPermission, r.DMPermission)
+	compare("nsfw", l.NSFW, r.NSFW)
+	return append(fields, diffOptions("options", l.Options, r.Options)...)
+}
+
+// diffOptions compares options by name, recursing into subcommands
+func diffOptions(prefix string, local, remote []canonicalOption) []string {
+	remoteByName := make(map[string]canonicalOption, len(remote))
+	for _, opt := range remote {
+		remoteByName[opt.Name] = opt
+	}
+
+	var fields []string
+	for _, l := range local {
+		path := prefix + "." + l.Name
+		r, ok := remoteByName[l.Name]
+		if !ok {
+			fields = append(fields, path+" (added)")
+			continue
+		}
+		delete(remoteByName, l.Name)
+
+		compare := func(name string, a, b interface{}) {
+			if !reflect.DeepEqual(a, b) {
+				fields = append(fields, path+"."+name)
+			}
+		}
+		compare("type", l.Type, r.Type)
+		compare("description", l.Description, r.Description)
+		compare("name_localizations", l.NameLocalizations, r.NameLocalizations)
+		compare("description_localizations", l.DescriptionLocalizations, r.DescriptionLocalizations)
+		compare("required", l.Required, r.Required)
+		compare("choices", l.Choices, r.Choices)
+		compare("channel_types", l.ChannelTypes, r.ChannelTypes)
+		compare("min_value", l.MinValue, r.MinValue)
+		compare("max_value", l.MaxValue, r.MaxValue)
+		compare("min_length", l.MinLength, r.MinLength)
+		compare("max_length", l.MaxLength, r.MaxLength)
+		compare("autocomplete", l.Autocomplete, r.Autocomplete)
+		fields = append(fields, diffOptions(path, l.Options, r.Options)...)
+	}
+
+	for _, r := range remote {
+		if _, ok := remoteByName[r.Name]; ok {
+			fields = append(fields, prefix+"."+r.Name+" (removed)")
+		}
+	}
+
+	// Discord shows options in the order they were sent
+	if !reflect.DeepEqual(commonNames(local, remote), commonNames(remote, local)) {
+		fields = append(fields, prefix+" (order)")
+	}
+	return fields
+}
+
+// commonNames returns the names of options that appear in both lists, in the order of the first
+func commonNames(options, other []canonicalOption) []string {
+	present := make(map[string]bool, len(other))
+	for _, opt := range other {
+		present[opt.Name] = true
+	}
+
+	var names []string
+	for _, opt := range options {
+		if present[opt.Name] {
+			names = append(names, opt.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// testCommand returns a fresh definition with a subcommand, choices and limits
+func testCommand() *discordgo.ApplicationCommand {
+	minLength := 1
+	minValue := float64(1)
+	return &discordgo.ApplicationCommand{
+		Name:        "roll",
+		Description: "Roll dice",
+		DescriptionLocalizations: &map[discordgo.Locale]string{
+			discordgo.ChineseTW: "擲骰子",
+		},
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "expression",
+				Description: "Dice expression",
+				Required:    true,
+				MinLength:   &minLength,
+				MaxLength:   200,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "times",
+				Description: "Number of rolls",
+				MinValue:    &minValue,
+				MaxValue:    10,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "one", Value: 1},
+					{Name: "two", Value: 2},
+				},
+			},
+		},
+	}
+}
+
+// discordCommand is testCommand as Discord returns it once registered
+const discordCommand = `{
+	"id": "1300000000000000001",
+	"application_id": "1200000000000000001",
+	"version": "1300000000000000002",
+	"type": 1,
+	"name": "roll",
+	"description": "Roll dice",
+	"description_localizations": {"zh-TW": "擲骰子"},
+	"default_member_permissions": null,
+	"dm_permission": true,
+	"nsfw": false,
+	"options": [
+		{
+			"type": 3,
+			"name": "expression",
+			"description": "Dice expression",
+			"required": true,
+			"min_length": 1,
+			"max_length": 200
+		},
+		{
+			"type": 4,
+			"name": "times",
+			"description": "Number of rolls",
+			"min_value": 1,
+			"max_value": 10,
+			"choices": [
+				{"name": "one", "value": 1},
+				{"name": "two", "value": 2}
+			]
+		}
+	]
+}`
+
+func TestHashMatchesRegisteredCommand(t *testing.T) {
+	var remote discordgo.ApplicationCommand
+	if err := json.Unmarshal([]byte(discordCommand), &remote); err != nil {
+		t.Fatal(err)
+	}
+
+	local := testCommand()
+	if diff := diffCommand(local, &remote); diff != nil {
+		t.Errorf("diffCommand() = %v, want no differences", diff)
+	}
+	if Hash(local) != Hash(&remote) {
+		t.Error("Hash() of the local definition differs from the registered command")
+	}
+
+	// Sending the definition and reading it back must not change the hash either
+	data, err := json.Marshal(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent discordgo.ApplicationCommand
+	if err := json.Unmarshal(data, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if Hash(local) != Hash(&sent) {
+		t.Error("Hash() changed after a JSON round trip")
+	}
+}
+
+func TestDiffCommand(t *testing.T) {
+	enabled, disabled := true, false
+	admin := int64(discordgo.PermissionAdministrator)
+
+	tests := []struct {
+		name   string
+		local  func(*discordgo.ApplicationCommand)
+		remote func(*discordgo.ApplicationCommand)
+		want   []string
+	}{
+		{
+			name: "identical",
+		},
+		{
+			name:   "dm permission defaults to allowed",
+			remote: func(c *discordgo.ApplicationCommand) { c.DMPermission = &enabled },
+		},
+		{
+			name:  "dm permission disabled",
+			local: func(c *discordgo.ApplicationCommand) { c.DMPermission = &disabled },
+			want:  []string{"dm_permission"},
+		},
+		{
+			name:   "default member permissions",
+			remote: func(c *discordgo.ApplicationCommand) { c.DefaultMemberPermissions = &admin },
+			want:   []string{"default_member_permissions"},
+		},
+		{
+			name:   "chat input type is the default",
+			remote: func(c *discordgo.ApplicationCommand) { c.Type = discordgo.ChatApplicationCommand },
+		},
+		{
+			name:  "empty localizations",
+			local: func(c *discordgo.ApplicationCommand) { c.NameLocalizations = &map[discordgo.Locale]string{} },
+		},
+		{
+			name:   "description",
+			remote: func(c *discordgo.ApplicationCommand) { c.Description = "Roll some dice" },
+			want:   []string{"description"},
+		},
+		{
+			name: "localized description",
+			remote: func(c *discordgo.ApplicationCommand) {
+				(*c.DescriptionLocalizations)[discordgo.ChineseTW] = "擲骰"
+			},
+			want: []string{"description_localizations"},
+		},
+		{
+			name: "integer choices returned as floats",
+			remote: func(c *discordgo.ApplicationCommand) {
+				c.Options[1].Choices[0].Value = float64(1)
+				c.Options[1].Choices[1].Value = float64(2)
+			},
+		},
+		{
+			name:   "choice value",
+			remote: func(c *discordgo.ApplicationCommand) { c.Options[1].Choices[1].Value = float64(3) },
+			want:   []string{"options.times.choices"},
+		},
+		{
+			name:   "float limit",
+			remote: func(c *discordgo.ApplicationCommand) { c.Options[1].MaxValue = 10.5 },
+			want:   []string{"options.times.max_value"},
+		},
+		{
+			name:   "empty channel types",
+			remote: func(c *discordgo.ApplicationCommand) { c.Options[0].ChannelTypes = []discordgo.ChannelType{} },
+		},
+		{
+			name: "option order",
+			remote: func(c *discordgo.ApplicationCommand) {
+				c.Options[0], c.Options[1] = c.Options[1], c.Options[0]
+			},
+			want: []string{"options (order)"},
+		},
+		{
+			name:   "option added",
+			remote: func(c *discordgo.ApplicationCommand) { c.Options = c.Options[:1] },
+			want:   []string{"options.times (added)"},
+		},
+		{
+			name:  "option removed",
+			local: func(c *discordgo.ApplicationCommand) { c.Options = c.Options[:1] },
+			want:  []string{"options.times (removed)"},
+		},
+		{
+			name: "nested option",
+			local: func(c *discordgo.ApplicationCommand) {
+				c.Options = []*discordgo.ApplicationCommandOption{
+					{Type: discordgo.ApplicationCommandOptionSubCommand, Name: "dice", Description: "Roll", Options: c.Options},
+				}
+			},
+			remote: func(c *discordgo.ApplicationCommand) {
+				c.Options[0].MaxLength = 100
+				c.Options = []*discordgo.ApplicationCommandOption{
+					{Type: discordgo.ApplicationCommandOptionSubCommand, Name: "dice", Description: "Roll", Options: c.Options},
+				}
+			},
+			want: []string{"options.dice.expression.max_length"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local, remote := testCommand(), testCommand()
+			if tt.local != nil {
+				tt.local(local)
+			}
+			if tt.remote != nil {
+				tt.remote(remote)
+			}
+
+			got := diffCommand(local, remote)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffCommand() = %v, want %v", got, tt.want)
+			}
+			if equal := Hash(local) == Hash(remote); equal != (tt.want == nil) {
+				t.Errorf("Hash() equal = %v, want %v", equal, tt.want == nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"hiei-discord-bot/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Doc is the localized documentation of a command, shared by /help and the
+// generated command docs. Texts come from the command.help.commands.<key>
+// translations and fall back to the definition:
+//
+//	description           short description (default: definition description)
+//	details               long description
+//	examples              usage examples, one per line
+//	sub.<path>            subcommand description, path like "image add"
+//	options.<path>        option description, path like "image add file"
+//	notes / notes.<path>  extra remarks, one per line, for the command or a subcommand
+type Doc struct {
+	Key        string
+	Definition *discordgo.ApplicationCommand
+	Version    string
+	Metadata   Metadata
+
+	Description string
+	Details     string
+	Examples    []string
+	Usages      []Usage
+}
+
+// Usage is one way to invoke a slash command: the command itself or one of its subcommands
+type Usage struct {
+	Path        []string // Subcommand group and subcommand names, empty for the command itself
+	Line        string   // e.g. "/blame image add <file> [name]"
+	Description string
+	Options     []OptionDoc
+	Notes       []string
+}
+
+// OptionDoc documents a single command option
+type OptionDoc struct {
+	Name        string
+	Description string
+	Required    bool
+	Choices     []string
+}
+
+// Describe returns a command's metadata with the defaults filled in
+func Describe(cmd Command) Metadata {
+	var meta Metadata
+	if describer, ok := cmd.(Describer); ok {
+		meta = describer.Describe()
+	}
+
+	key := Key(cmd.Definition())
+	if meta.Category == "" {
+		meta.Category = CategoryOther
+	}
+	if meta.DetailsKey == "" {
+		meta.DetailsKey = docKey(key, "details")
+	}
+	if meta.ExamplesKey == "" {
+		meta.ExamplesKey = docKey(key, "examples")
+	}
+	return meta
+}
+
+// Document builds the documentation of a command in the given locale
+func Document(cmd Command, locale i18n.SupportedLocale) Doc {
+	def := cmd.Definition()
+	key := Key(def)
+	meta := Describe(cmd)
+	if def.DefaultMemberPermissions != nil {
+		meta.Permissions |= *def.DefaultMemberPermissions
+	}
+
+	doc := Doc{
+		Key:         key,
+		Definition:  def,
+		Version:     cmd.Version(),
+		Metadata:    meta,
+		Description: i18n.TDefault(locale, docKey(key, "description"), def.Description),
+		Details:     i18n.TDefault(locale, meta.DetailsKey, ""),
+		Examples:    lines(i18n.TDefault(locale, meta.ExamplesKey, "")),
+	}
+	if def.Type == discordgo.UserApplicationCommand || def.Type == discordgo.MessageApplicationCommand {
+		return doc
+	}
+
+	var walk func(path []string, options []*discordgo.ApplicationCommandOption)
+	walk = func(path []string, options []*discordgo.ApplicationCommandOption) {
+		for _, opt := range options {
+			subPath := append(append([]string{}, path...), opt.Name)
+			switch opt.Type {
+			case discordgo.ApplicationCommandOptionSubCommandGroup:
+				walk(subPath, opt.Options)
+			case discordgo.ApplicationCommandOptionSubCommand:
+				doc.Usages = append(doc.Usages, usage(locale, key, def.Name, subPath,
+					i18n.TDefault(locale, docKey(key, "sub."+strings.Join(subPath, " ")), opt.Description), opt.Options))
+			}
+		}
+	}
+	walk(nil, def.Options)
+
+	if len(doc.Usages) == 0 {
+		doc.Usages = append(doc.Usages, usage(locale, key, def.Name, nil, doc.Description, def.Options))
+	}
+	return doc
+}
+
+// usage documents an invocation and its options
+func usage(locale i18n.SupportedLocale, key, name string, path []string, description string, options []*discordgo.ApplicationCommandOption) Usage {
+	parts := append([]string{"/" + name}, path...)
+	notesKey := docKey(key, "notes")
+	if len(path) > 0 {
+		notesKey += "." + strings.Join(path, " ")
+	}
+
+	u := Usage{
+		Path:        path,
+		Description: description,
+		Notes:       lines(i18n.TDefault(locale, notesKey, "")),
+	}
+	for _, opt := range options {
+		if opt.Required {
+			parts = append(parts, "<"+opt.Name+">")
+		} else {
+			parts = append(parts, "["+opt.Name+"]")
+		}
+
+		optionPath := strings.Join(append(append([]string{}, path...), opt.Name), " ")
+		option := OptionDoc{
+			Name:        opt.Name,
+			Description: i18n.TDefault(locale, docKey(key, "options."+optionPath), opt.Description),
+			Required:    opt.Required,
+		}
+		for _, choice := range opt.Choices {
+			option.Choices = append(option.Choices, fmt.Sprint(choice.Value))
+		}
+		u.Options = append(u.Options, option)
+	}
+	u.Line = strings.Join(parts, " ")
+	return u
+}
+
+// permissionNames lists the permissions named in docs, in display order
+var permissionNames = []struct {
+	permission int64
+	name       string
+}{
+	{discordgo.PermissionAdministrator, "administrator"},
+	{discordgo.PermissionManageGuild, "manage_guild"},
+	{discordgo.PermissionManageChannels, "manage_channels"},
+	{discordgo.PermissionManageRoles, "manage_roles"},
+	{discordgo.PermissionManageMessages, "manage_messages"},
+}
+
+// PermissionNames returns the translated names of the known permissions in a set
+func PermissionNames(locale i18n.SupportedLocale, permissions int64) string {
+	var names []string
+	for _, known := range permissionNames {
+		if permissions&known.permission != 0 {
+			names = append(names, i18n.T(locale, "command.help.permission."+known.name))
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// SortCategories returns the categories of a grouping in display order,
+// with unknown categories last by name
+func SortCategories[T any](byCategory map[string]T) []string {
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(a, b int) bool {
+		rankA, rankB := categoryRank(categories[a]), categoryRank(categories[b])
+		if rankA != rankB {
+			return rankA < rankB
+		}
+		return categories[a] < categories[b]
+	})
+	return categories
+}
+
+// SortDocs orders docs with slash commands first, then user and message menus, each by key
+func SortDocs(docs []Doc) {
+	sort.SliceStable(docs, func(a, b int) bool {
+		typeA, typeB := commandTypeRank(docs[a].Definition.Type), commandTypeRank(docs[b].Definition.Type)
+		if typeA != typeB {
+			return typeA < typeB
+		}
+		return docs[a].Key < docs[b].Key
+	})
+}
+
+func categoryRank(category string) int {
+	for idx, known := range Categories {
+		if known == category {
+			return idx
+		}
+	}
+	return len(Categories)
+}
+
+func commandTypeRank(commandType discordgo.ApplicationCommandType) int {
+	switch commandType {
+	case discordgo.UserApplicationCommand:
+		return 1
+	case discordgo.MessageApplicationCommand:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// docKey returns the translation key of a command's help text
+func docKey(key, name string) string {
+	return fmt.Sprintf("command.help.commands.%s.%s", key, name)
+}
+
+// lines splits a newline-separated translation, dropping empty lines
+func lines(text string) []string {
+	var result []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
@@ -41,7 +41,12 @@ func (c *Command) Definition() *discordgo.ApplicationCommand {
 
 // Version returns the command version
 func (c *Command) Version() string {
-	return "1.1.0"
+	return "1.6.0"
+}
+
+// Describe returns the help metadata
+func (c *Command) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryGames}
 }
 
 // Execute runs the game command
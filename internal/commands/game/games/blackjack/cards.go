@@ -0,0 +1,144 @@
+package blackjack
+
+import (
+	"math/rand"
+	"strings"
+)
+
+const (
+	// shoeDecks is the number of decks shuffled into a shoe
+	shoeDecks = 6
+
+	// reshuffleRatio is the fraction of remaining cards that triggers a reshuffle
+	reshuffleRatio = 0.25
+)
+
+var (
+	ranks = []string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}
+	suits = []string{"♠️", "♥️", "♦️", "♣️"}
+)
+
+// hiddenCard is shown in place of the dealer's hole card
+const hiddenCard = "🂠"
+
+// Card is a single playing card
+type Card struct {
+	Rank int // Index into ranks (0 = Ace, 12 = King)
+	Suit int // Index into suits
+}
+
+// Value returns the blackjack value of the card, counting aces as 1
+func (c Card) Value() int {
+	if c.Rank >= 9 {
+		return 10
+	}
+	return c.Rank + 1
+}
+
+// String renders the card with its suit emoji
+func (c Card) String() string {
+	return "`" + ranks[c.Rank] + "`" + suits[c.Suit]
+}
+
+// Shoe is a seeded multi-deck card shoe
+type Shoe struct {
+	cards []Card
+	next  int
+	rng   *rand.Rand
+}
+
+// NewShoe creates a shuffled shoe of the given number of decks.
+// The same seed always produces the same sequence of cards.
+func NewShoe(decks int, seed int64) *Shoe {
+	shoe := &Shoe{
+		cards: make([]Card, 0, decks*len(ranks)*len(suits)),
+		rng:   rand.New(rand.NewSource(seed)),
+	}
+	for d := 0; d < decks; d++ {
+		for suit := range suits {
+			for rank := range ranks {
+				shoe.cards = append(shoe.cards, Card{Rank: rank, Suit: suit})
+			}
+		}
+	}
+	shoe.shuffle()
+	return shoe
+}
+
+// Draw deals the next card, reshuffling when the shoe runs low
+func (s *Shoe) Draw() Card {
+	if float64(len(s.cards)-s.next) < float64(len(s.cards))*reshuffleRatio {
+		s.shuffle()
+	}
+	card := s.cards[s.next]
+	s.next++
+	return card
+}
+
+func (s *Shoe) shuffle() {
+	s.rng.Shuffle(len(s.cards), func(i, j int) {
+		s.cards[i], s.cards[j] = s.cards[j], s.cards[i]
+	})
+	s.next = 0
+}
+
+// Hand is a set of cards with the bet placed on it
+type Hand struct {
+	Cards     []Card
+	Bet       int64
+	Doubled   bool
+	Stood     bool
+	FromSplit bool
+}
+
+// Value returns the best total of the hand and whether it is soft
+// (an ace is counted as 11)
+func (h *Hand) Value() (int, bool) {
+	total := 0
+	aces := 0
+	for _, card := range h.Cards {
+		total += card.Value()
+		if card.Rank == 0 {
+			aces++
+		}
+	}
+	if aces > 0 && total+10 <= 21 {
+		return total + 10, true
+	}
+	return total, false
+}
+
+// Total returns the best total of the hand
+func (h *Hand) Total() int {
+	total, _ := h.Value()
+	return total
+}
+
+// IsBust reports whether the hand exceeds 21
+func (h *Hand) IsBust() bool {
+	return h.Total() > 21
+}
+
+// IsBlackjack reports whether the hand is a natural blackjack.
+// Hands created by splitting never count as blackjack.
+func (h *Hand) IsBlackjack() bool {
+	return len(h.Cards) == 2 && h.Total() == 21 && !h.FromSplit
+}
+
+// IsDone reports whether the player can no longer act on the hand
+func (h *Hand) IsDone() bool {
+	return h.Stood || h.Doubled || h.Total() >= 21
+}
+
+// Render renders the hand, optionally hiding every card after the first
+func (h *Hand) Render(hideHole bool) string {
+	parts := make([]string, 0, len(h.Cards))
+	for idx, card := range h.Cards {
+		if hideHole && idx > 0 {
+			parts = append(parts, hiddenCard)
+			continue
+		}
+		parts = append(parts, card.String())
+	}
+	return strings.Join(parts, " ")
+}
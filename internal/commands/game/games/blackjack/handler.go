@@ -0,0 +1,284 @@
+package blackjack
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"hiei-discord-bot/internal/economy"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	defaultBet int64 = 100
+	minBet     int64 = 10
+	maxBet     int64 = 5000
+)
+
+// outcomeKeys maps each outcome to its translation key
+var outcomeKeys = map[Outcome]string{
+	OutcomeLose:      "game.blackjack.outcome.lose",
+	OutcomePush:      "game.blackjack.outcome.push",
+	OutcomeWin:       "game.blackjack.outcome.win",
+	OutcomeBlackjack: "game.blackjack.outcome.blackjack",
+}
+
+// HandleStart places the bet and deals a new Blackjack game
+func HandleStart(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	manager := GetManager()
+	wallet := economy.GetManager()
+
+	userID := interactions.UserID(i)
+	if userID == "" {
+		return fmt.Errorf("could not get user ID")
+	}
+
+	bet := defaultBet
+	options := i.ApplicationCommandData().Options
+	if len(options) > 0 {
+		for _, opt := range options[0].Options {
+			if opt.Name == "bet" {
+				bet = opt.IntValue()
+			}
+		}
+	}
+	if bet < minBet || bet > maxBet {
+		return interactions.RespondError(s, i, locale, "game.blackjack.error.invalid_bet", true, minBet, maxBet)
+	}
+
+	// The game is registered before the bet is placed, so a second start cannot
+	// debit another bet; it stays locked until the bet is placed
+	game, started := manager.StartGame(userID, bet, 0, locale)
+	if !started {
+		return interactions.RespondError(s, i, locale, "game.blackjack.error.already_active", true)
+	}
+	defer game.mu.Unlock()
+
+	refill, err := wallet.ClaimDailyRefill(userID)
+	if err != nil {
+		manager.EndGame(userID, game)
+		return fmt.Errorf("failed to claim daily refill: %w", err)
+	}
+
+	balance, err := wallet.Debit(userID, bet)
+	if err != nil {
+		manager.EndGame(userID, game)
+		if errors.Is(err, economy.ErrInsufficientChips) {
+			return interactions.RespondError(s, i, locale, "game.blackjack.error.insufficient_chips", true, balance)
+		}
+		return fmt.Errorf("failed to place bet: %w", err)
+	}
+
+	var notice string
+	if refill > 0 {
+		notice = i18n.Tf(locale, "game.blackjack.daily_refill", refill)
+	}
+
+	message, err := buildTurnMessage(userID, game, notice)
+	if err != nil {
+		return err
+	}
+	return interactions.RespondCustom(s, i, message)
+}
+
+// HandleButtonClick handles Hit/Stand/Double/Split buttons
+func HandleButtonClick(s *discordgo.Session, i *discordgo.InteractionCreate, action string) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	manager := GetManager()
+	wallet := economy.GetManager()
+
+	userID := interactions.UserID(i)
+	if userID == "" {
+		return fmt.Errorf("could not get user ID")
+	}
+
+	game, exists := manager.GetGame(userID)
+	if !exists {
+		return interactions.RespondError(s, i, locale, "game.blackjack.error.no_active_game", true)
+	}
+
+	// Clicks are handled one at a time; a click that waited may find the game finished
+	game.mu.Lock()
+	defer game.mu.Unlock()
+	if game.Finished {
+		return interactions.RespondError(s, i, locale, "game.blackjack.error.no_active_game", true)
+	}
+
+	switch action {
+	case "hit":
+		game.Hit()
+
+	case "stand":
+		game.Stand()
+
+	case "double", "split":
+		allowed := game.CanDouble()
+		if action == "split" {
+			allowed = game.CanSplit()
+		}
+		if !allowed {
+			return interactions.RespondError(s, i, locale, "game.blackjack.error.not_allowed", true)
+		}
+
+		// Doubling and splitting both require matching the active hand's bet
+		balance, err := wallet.Debit(userID, game.ActiveHand().Bet)
+		if errors.Is(err, economy.ErrInsufficientChips) {
+			return interactions.RespondError(s, i, locale, "game.blackjack.error.insufficient_chips", true, balance)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to place additional bet: %w", err)
+		}
+
+		if action == "double" {
+			game.Double()
+		} else {
+			game.Split()
+		}
+
+	default:
+		return interactions.RespondError(s, i, locale, "command.unknown", true)
+	}
+
+	message, err := buildTurnMessage(userID, game, "")
+	if err != nil {
+		return err
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: message,
+	})
+}
+
+// buildTurnMessage renders the game and, if it just finished, pays out the winnings
+func buildTurnMessage(userID string, game *GameState, notice string) (*discordgo.InteractionResponseData, error) {
+	if !game.Finished {
+		balance, err := economy.GetManager().Balance(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chip balance: %w", err)
+		}
+		message := buildGameMessage(game, balance, notice)
+		message.Components = buildGameButtons(userID, game)
+		return message, nil
+	}
+
+	// Only the request that ends the game pays out the winnings
+	if !GetManager().EndGame(userID, game) {
+		return nil, fmt.Errorf("blackjack game of %s was already paid out", userID)
+	}
+	balance, err := economy.GetManager().Credit(userID, game.Payout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pay out winnings: %w", err)
+	}
+
+	message := buildGameMessage(game, balance, notice)
+	message.Content += "\n\n" + buildResult(game)
+	message.Components = []discordgo.MessageComponent{} // Remove buttons
+	return message, nil
+}
+
+// buildGameMessage builds the table view
+func buildGameMessage(game *GameState, balance int64, notice string) *discordgo.InteractionResponseData {
+	locale := game.Locale
+	var builder strings.Builder
+
+	builder.WriteString(i18n.Tf(locale, "game.blackjack.title_with_bet", game.TotalBet()))
+	builder.WriteString("\n\n")
+
+	// Dealer hand, with the hole card hidden while the player is acting
+	if game.Finished {
+		builder.WriteString(i18n.Tf(locale, "game.blackjack.dealer", game.Dealer.Render(false), game.Dealer.Total()))
+	} else {
+		builder.WriteString(i18n.Tf(locale, "game.blackjack.dealer_hidden", game.Dealer.Render(true), game.Dealer.Cards[0].Value()))
+	}
+	builder.WriteString("\n\n")
+
+	// Player hands
+	for idx, hand := range game.Hands {
+		marker := "▫️"
+		if !game.Finished && idx == game.Active {
+			marker = "▶️"
+		}
+		label := i18n.T(locale, "game.blackjack.your_hand")
+		if len(game.Hands) > 1 {
+			label = i18n.Tf(locale, "game.blackjack.hand_number", idx+1)
+		}
+		builder.WriteString(i18n.Tf(locale, "game.blackjack.hand", marker, label, hand.Render(false), hand.Total(), hand.Bet))
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("\n" + i18n.Tf(locale, "game.blackjack.balance", balance))
+
+	if notice != "" {
+		builder.WriteString("\n" + notice)
+	}
+
+	return &discordgo.InteractionResponseData{
+		Content: builder.String(),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	}
+}
+
+// buildResult summarizes the outcome of every hand and the net result
+func buildResult(game *GameState) string {
+	locale := game.Locale
+	var builder strings.Builder
+
+	for idx, outcome := range game.Outcomes {
+		label := i18n.T(locale, "game.blackjack.your_hand")
+		if len(game.Hands) > 1 {
+			label = i18n.Tf(locale, "game.blackjack.hand_number", idx+1)
+		}
+		builder.WriteString(label + ": " + i18n.T(locale, outcomeKeys[outcome]) + "\n")
+	}
+
+	net := game.Payout - game.TotalBet()
+	switch {
+	case net > 0:
+		builder.WriteString(i18n.Tf(locale, "game.blackjack.result.won", net))
+	case net < 0:
+		builder.WriteString(i18n.Tf(locale, "game.blackjack.result.lost", -net))
+	default:
+		builder.WriteString(i18n.T(locale, "game.blackjack.result.push"))
+	}
+
+	builder.WriteString("\n" + i18n.Tf(locale, "game.blackjack.seed", game.Seed))
+	return builder.String()
+}
+
+// buildGameButtons creates the action buttons for the active hand
+func buildGameButtons(userID string, game *GameState) []discordgo.MessageComponent {
+	locale := game.Locale
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    i18n.T(locale, "game.blackjack.button.hit"),
+					Style:    discordgo.PrimaryButton,
+					CustomID: fmt.Sprintf("game_blackjack_hit_%s", userID),
+				},
+				discordgo.Button{
+					Label:    i18n.T(locale, "game.blackjack.button.stand"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("game_blackjack_stand_%s", userID),
+				},
+				discordgo.Button{
+					Label:    i18n.T(locale, "game.blackjack.button.double"),
+					Style:    discordgo.SuccessButton,
+					CustomID: fmt.Sprintf("game_blackjack_double_%s", userID),
+					Disabled: !game.CanDouble(),
+				},
+				discordgo.Button{
+					Label:    i18n.T(locale, "game.blackjack.button.split"),
+					Style:    discordgo.SuccessButton,
+					CustomID: fmt.Sprintf("game_blackjack_split_%s", userID),
+					Disabled: !game.CanSplit(),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package blackjack
+
+import (
+	"hiei-discord-bot/internal/commands/game"
+	"hiei-discord-bot/internal/interactions"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func init() {
+	router := interactions.GetRouter()
+
+	// Register component handler (buttons)
+	router.RegisterComponent("game_blackjack_", handleComponentInteraction)
+
+	// Register as game subcommand
+	game.RegisterSubCommand(&SubCommand{})
+}
+
+// SubCommand implements game.SubCommand interface
+type SubCommand struct{}
+
+func (s *SubCommand) Name() string {
+	return "blackjack"
+}
+
+func (s *SubCommand) Description() string {
+	return "Play Blackjack against the dealer with your chips"
+}
+
+func (s *SubCommand) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "bet",
+			Description: "Chips to bet (10-5000, default: 100)",
+			Required:    false,
+			MinValue:    float64Ptr(float64(minBet)),
+			MaxValue:    float64(maxBet),
+		},
+	}
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func (s *SubCommand) Handle(session *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return HandleStart(session, i)
+}
+
+// handleComponentInteraction routes button interactions to the appropriate handler
+func handleComponentInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	customID := i.MessageComponentData().CustomID
+
+	// Extract action from customID: game_blackjack_{action}_{userID}
+	parts := strings.Split(customID, "_")
+	if len(parts) < 4 {
+		return nil
+	}
+
+	action := parts[2] // "hit", "stand", "double" or "split"
+	return HandleButtonClick(s, i, action)
+}
+
+// ActiveSessions returns the number of games in progress
+func (s *SubCommand) ActiveSessions() int {
+	return GetManager().ActiveGames()
+}
@@ -0,0 +1,291 @@
+package blackjack
+
+import (
+	"sync"
+	"time"
+
+	"hiei-discord-bot/internal/i18n"
+)
+
+const (
+	// maxHands limits how many hands a player can hold after splitting
+	maxHands = 4
+
+	// dealerStandTotal is the total at which the dealer stands (including soft 17)
+	dealerStandTotal = 17
+)
+
+// Outcome is the result of a single hand against the dealer
+type Outcome int
+
+const (
+	OutcomeLose Outcome = iota
+	OutcomePush
+	OutcomeWin
+	OutcomeBlackjack
+)
+
+// GameState represents a Blackjack game session
+type GameState struct {
+	Seed     int64
+	Shoe     *Shoe
+	Hands    []*Hand
+	Active   int // Index of the hand the player is acting on
+	Dealer   *Hand
+	Finished bool
+	Outcomes []Outcome
+	Payout   int64 // Chips returned to the player when the game finished
+	Locale   i18n.SupportedLocale
+
+	// mu serializes the actions on the game, so repeated clicks cannot place
+	// an additional bet or pay out the winnings twice
+	mu sync.Mutex
+}
+
+// Manager manages active Blackjack game sessions
+type Manager struct {
+	games map[string]*GameState // userID -> GameState
+	mu    sync.RWMutex
+}
+
+var instance *Manager
+var once sync.Once
+
+// GetManager returns the singleton game manager
+func GetManager() *Manager {
+	once.Do(func() {
+		instance = &Manager{
+			games: make(map[string]*GameState),
+		}
+	})
+	return instance
+}
+
+// StartGame deals a new game for a user with the given bet, unless the user
+// already has one. If seed is zero a time-based seed is used.
+//
+// The game is returned locked, so no action reaches it before the caller has
+// placed the bet; the caller unlocks it.
+func (m *Manager) StartGame(userID string, bet int64, seed int64, locale i18n.SupportedLocale) (*GameState, bool) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.games[userID]; exists {
+		return nil, false
+	}
+
+	game := NewGame(bet, seed, locale)
+	game.mu.Lock()
+	m.games[userID] = game
+	return game, true
+}
+
+// GetGame retrieves an active game for a user
+func (m *Manager) GetGame(userID string) (*GameState, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	game, exists := m.games[userID]
+	return game, exists
+}
+
+// EndGame removes a user's game session if it is still the given game, and
+// reports whether it did
+func (m *Manager) EndGame(userID string, game *GameState) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.games[userID] != game {
+		return false
+	}
+	delete(m.games, userID)
+	return true
+}
+
+// ActiveGames returns the number of games in progress
+func (m *Manager) ActiveGames() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.games)
+}
+
+// NewGame deals the opening hands from a shoe built with the given seed.
+// Naturals are resolved immediately, as if the dealer peeked at the hole card.
+func NewGame(bet int64, seed int64, locale i18n.SupportedLocale) *GameState {
+	shoe := NewShoe(shoeDecks, seed)
+	player := &Hand{Bet: bet}
+	dealer := &Hand{}
+
+	player.Cards = append(player.Cards, shoe.Draw())
+	dealer.Cards = append(dealer.Cards, shoe.Draw())
+	player.Cards = append(player.Cards, shoe.Draw())
+	dealer.Cards = append(dealer.Cards, shoe.Draw())
+
+	game := &GameState{
+		Seed:   seed,
+		Shoe:   shoe,
+		Hands:  []*Hand{player},
+		Dealer: dealer,
+		Locale: locale,
+	}
+
+	if player.IsBlackjack() || dealer.IsBlackjack() {
+		player.Stood = true
+		game.settle()
+	}
+
+	return game
+}
+
+// ActiveHand returns the hand the player is currently acting on
+func (g *GameState) ActiveHand() *Hand {
+	if g.Active >= len(g.Hands) {
+		return nil
+	}
+	return g.Hands[g.Active]
+}
+
+// Hit draws a card to the active hand
+func (g *GameState) Hit() {
+	hand := g.ActiveHand()
+	if g.Finished || hand == nil {
+		return
+	}
+	hand.Cards = append(hand.Cards, g.Shoe.Draw())
+	g.advance()
+}
+
+// Stand ends the turn of the active hand
+func (g *GameState) Stand() {
+	hand := g.ActiveHand()
+	if g.Finished || hand == nil {
+		return
+	}
+	hand.Stood = true
+	g.advance()
+}
+
+// CanDouble reports whether the active hand may be doubled
+func (g *GameState) CanDouble() bool {
+	hand := g.ActiveHand()
+	return !g.Finished && hand != nil && len(hand.Cards) == 2
+}
+
+// Double doubles the bet of the active hand and draws exactly one more card.
+// The caller is responsible for debiting the additional bet.
+func (g *GameState) Double() {
+	if !g.CanDouble() {
+		return
+	}
+	hand := g.ActiveHand()
+	hand.Bet *= 2
+	hand.Doubled = true
+	hand.Cards = append(hand.Cards, g.Shoe.Draw())
+	g.advance()
+}
+
+// CanSplit reports whether the active hand is a pair that may be split
+func (g *GameState) CanSplit() bool {
+	hand := g.ActiveHand()
+	return !g.Finished && hand != nil && len(hand.Cards) == 2 &&
+		hand.Cards[0].Rank == hand.Cards[1].Rank && len(g.Hands) < maxHands
+}
+
+// Split splits the active pair into two hands with the same bet.
+// Split aces receive one card each and cannot be played further.
+// The caller is responsible for debiting the additional bet.
+func (g *GameState) Split() {
+	if !g.CanSplit() {
+		return
+	}
+	hand := g.ActiveHand()
+	splitAces := hand.Cards[0].Rank == 0
+
+	second := &Hand{
+		Cards:     []Card{hand.Cards[1], g.Shoe.Draw()},
+		Bet:       hand.Bet,
+		FromSplit: true,
+		Stood:     splitAces,
+	}
+	hand.Cards = []Card{hand.Cards[0], g.Shoe.Draw()}
+	hand.FromSplit = true
+	hand.Stood = splitAces
+
+	// Insert the new hand right after the active one
+	g.Hands = append(g.Hands[:g.Active+1], append([]*Hand{second}, g.Hands[g.Active+1:]...)...)
+	g.advance()
+}
+
+// TotalBet returns the sum of the bets of all hands
+func (g *GameState) TotalBet() int64 {
+	var total int64
+	for _, hand := range g.Hands {
+		total += hand.Bet
+	}
+	return total
+}
+
+// advance moves to the next playable hand, finishing the game after the last one
+func (g *GameState) advance() {
+	for g.Active < len(g.Hands) && g.Hands[g.Active].IsDone() {
+		g.Active++
+	}
+	if g.Active >= len(g.Hands) {
+		g.playDealer()
+		g.settle()
+	}
+}
+
+// playDealer draws dealer cards until the dealer reaches dealerStandTotal.
+// The dealer does not draw if every player hand is bust.
+func (g *GameState) playDealer() {
+	for _, hand := range g.Hands {
+		if !hand.IsBust() {
+			for g.Dealer.Total() < dealerStandTotal {
+				g.Dealer.Cards = append(g.Dealer.Cards, g.Shoe.Draw())
+			}
+			return
+		}
+	}
+}
+
+// settle computes the outcome and payout of every hand
+func (g *GameState) settle() {
+	g.Finished = true
+	g.Outcomes = make([]Outcome, len(g.Hands))
+	g.Payout = 0
+
+	dealerTotal := g.Dealer.Total()
+	for idx, hand := range g.Hands {
+		var outcome Outcome
+		switch {
+		case hand.IsBust():
+			outcome = OutcomeLose
+		case hand.IsBlackjack() && !g.Dealer.IsBlackjack():
+			outcome = OutcomeBlackjack
+		case g.Dealer.IsBlackjack() && !hand.IsBlackjack():
+			outcome = OutcomeLose
+		case g.Dealer.IsBust() || hand.Total() > dealerTotal:
+			outcome = OutcomeWin
+		case hand.Total() == dealerTotal:
+			outcome = OutcomePush
+		default:
+			outcome = OutcomeLose
+		}
+
+		g.Outcomes[idx] = outcome
+		switch outcome {
+		case OutcomeBlackjack:
+			g.Payout += hand.Bet + hand.Bet*3/2
+		case OutcomeWin:
+			g.Payout += hand.Bet * 2
+		case OutcomePush:
+			g.Payout += hand.Bet
+		}
+	}
+}
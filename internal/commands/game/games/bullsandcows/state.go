@@ -90,6 +90,14 @@ func (m *Manager) EndGame(userID string) {
 	delete(m.games, userID)
 }
 
+// ActiveGames returns the number of games in progress
+func (m *Manager) ActiveGames() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.games)
+}
+
 // generateAnswer generates a random 4-digit number based on difficulty
 func generateAnswer(difficulty Difficulty) string {
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -0,0 +1,212 @@
+package game2048
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"hiei-discord-bot/internal/highscores"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// highscoreGame is the game key used for best scores
+const highscoreGame = "2048"
+
+// cellWidth is the width of a board cell in characters
+const cellWidth = 6
+
+// HandleStart starts a new 2048 game
+func HandleStart(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	manager := GetManager()
+
+	userID := interactions.UserID(i)
+	if userID == "" {
+		return fmt.Errorf("could not get user ID")
+	}
+
+	if _, exists := manager.GetGame(userID); exists {
+		return interactions.RespondError(s, i, locale, "game.2048.error.already_active", true)
+	}
+
+	game := manager.StartGame(userID, 0, locale)
+
+	message := buildGameMessage(game, bestScore(userID))
+	message.Components = buildGameButtons(userID, game)
+
+	return interactions.RespondCustom(s, i, message)
+}
+
+// HandleButtonClick handles direction, undo and give up buttons
+func HandleButtonClick(s *discordgo.Session, i *discordgo.InteractionCreate, action string) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	manager := GetManager()
+
+	userID := interactions.UserID(i)
+	if userID == "" {
+		return fmt.Errorf("could not get user ID")
+	}
+
+	game, exists := manager.GetGame(userID)
+	if !exists {
+		return interactions.RespondError(s, i, locale, "game.2048.error.no_active_game", true)
+	}
+
+	var message *discordgo.InteractionResponseData
+
+	switch action {
+	case string(DirectionUp), string(DirectionDown), string(DirectionLeft), string(DirectionRight):
+		if !game.Move(Direction(action)) {
+			return interactions.RespondError(s, i, locale, "game.2048.error.no_move", true)
+		}
+
+		if game.IsOver() {
+			message = finishGame(userID, game, "game.2048.result.over")
+		} else {
+			message = buildGameMessage(game, bestScore(userID))
+			message.Components = buildGameButtons(userID, game)
+		}
+
+	case "undo":
+		if !game.Undo() {
+			return interactions.RespondError(s, i, locale, "game.2048.error.no_undo", true)
+		}
+		message = buildGameMessage(game, bestScore(userID))
+		message.Components = buildGameButtons(userID, game)
+
+	case "giveup":
+		message = finishGame(userID, game, "game.2048.result.giveup")
+
+	default:
+		return interactions.RespondError(s, i, locale, "command.unknown", true)
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: message,
+	})
+}
+
+// finishGame ends the game, records the score and builds the final message
+func finishGame(userID string, game *GameState, resultKey string) *discordgo.InteractionResponseData {
+	GetManager().EndGame(userID)
+
+	best, isRecord, err := highscores.GetManager().Submit(highscoreGame, userID, game.Score)
+	if err != nil {
+		slog.Error("Failed to record 2048 score", "user_id", userID, "error", err)
+	}
+
+	message := buildGameMessage(game, best)
+	message.Content += "\n\n" + i18n.Tf(game.Locale, resultKey, game.Score, game.MaxTile())
+	if isRecord {
+		message.Content += "\n" + i18n.T(game.Locale, "game.2048.result.new_record")
+	}
+	message.Components = []discordgo.MessageComponent{} // Remove buttons
+	return message
+}
+
+// bestScore returns the user's recorded best score, logging lookup failures
+func bestScore(userID string) int64 {
+	best, err := highscores.GetManager().Best(highscoreGame, userID)
+	if err != nil {
+		slog.Error("Failed to get 2048 best score", "user_id", userID, "error", err)
+	}
+	return best
+}
+
+// buildGameMessage builds the game status message
+func buildGameMessage(game *GameState, best int64) *discordgo.InteractionResponseData {
+	locale := game.Locale
+	var builder strings.Builder
+
+	builder.WriteString(i18n.T(locale, "game.2048.title"))
+	builder.WriteString("\n\n")
+	builder.WriteString(i18n.Tf(locale, "game.2048.score", game.Score, best, game.Moves))
+	builder.WriteString("\n")
+	builder.WriteString(renderBoard(game.Board))
+
+	if game.ReachedGoal {
+		builder.WriteString("\n" + i18n.T(locale, "game.2048.reached_goal"))
+	}
+
+	return &discordgo.InteractionResponseData{
+		Content: builder.String(),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	}
+}
+
+// renderBoard renders the board as a monospace grid
+func renderBoard(board Board) string {
+	separator := "+" + strings.Repeat(strings.Repeat("-", cellWidth)+"+", boardSize) + "\n"
+
+	var builder strings.Builder
+	builder.WriteString("```\n")
+	builder.WriteString(separator)
+	for _, row := range board {
+		builder.WriteString("|")
+		for _, value := range row {
+			label := ""
+			if value != 0 {
+				label = strconv.Itoa(value)
+			}
+			// Center the value within the cell
+			left := (cellWidth - len(label)) / 2
+			right := cellWidth - len(label) - left
+			builder.WriteString(strings.Repeat(" ", left) + label + strings.Repeat(" ", right) + "|")
+		}
+		builder.WriteString("\n")
+		builder.WriteString(separator)
+	}
+	builder.WriteString("```")
+	return builder.String()
+}
+
+// buildGameButtons creates the directional pad with undo and give up buttons
+func buildGameButtons(userID string, game *GameState) []discordgo.MessageComponent {
+	locale := game.Locale
+	button := func(action, emoji string) discordgo.Button {
+		return discordgo.Button{
+			Style:    discordgo.PrimaryButton,
+			CustomID: fmt.Sprintf("game_2048_%s_%s", action, userID),
+			Emoji: &discordgo.ComponentEmoji{
+				Name: emoji,
+			},
+		}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    i18n.T(locale, "game.2048.button.undo"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("game_2048_undo_%s", userID),
+					Disabled: !game.CanUndo(),
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "↩️",
+					},
+				},
+				button(string(DirectionUp), "⬆️"),
+				discordgo.Button{
+					Label:    i18n.T(locale, "game.2048.button.giveup"),
+					Style:    discordgo.DangerButton,
+					CustomID: fmt.Sprintf("game_2048_giveup_%s", userID),
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "🏳️",
+					},
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				button(string(DirectionLeft), "⬅️"),
+				button(string(DirectionDown), "⬇️"),
+				button(string(DirectionRight), "➡️"),
+			},
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package game2048
+
+import (
+	"hiei-discord-bot/internal/commands/game"
+	"hiei-discord-bot/internal/interactions"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func init() {
+	router := interactions.GetRouter()
+
+	// Register component handler (buttons)
+	router.RegisterComponent("game_2048_", handleComponentInteraction)
+
+	// Register as game subcommand
+	game.RegisterSubCommand(&SubCommand{})
+}
+
+// SubCommand implements game.SubCommand interface
+type SubCommand struct{}
+
+func (s *SubCommand) Name() string {
+	return "2048"
+}
+
+func (s *SubCommand) Description() string {
+	return "Play the 2048 sliding tile puzzle"
+}
+
+func (s *SubCommand) Options() []*discordgo.ApplicationCommandOption {
+	return nil
+}
+
+func (s *SubCommand) Handle(session *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return HandleStart(session, i)
+}
+
+// handleComponentInteraction routes button interactions to the appropriate handler
+func handleComponentInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	customID := i.MessageComponentData().CustomID
+
+	// Extract action from customID: game_2048_{action}_{userID}
+	parts := strings.Split(customID, "_")
+	if len(parts) < 4 {
+		return nil
+	}
+
+	action := parts[2] // direction, "undo" or "giveup"
+	return HandleButtonClick(s, i, action)
+}
+
+// ActiveSessions returns the number of games in progress
+func (s *SubCommand) ActiveSessions() int {
+	return GetManager().ActiveGames()
+}
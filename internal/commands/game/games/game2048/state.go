@@ -0,0 +1,293 @@
+package game2048
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"hiei-discord-bot/internal/i18n"
+)
+
+const (
+	boardSize = 4
+	goalTile  = 2048
+
+	// fourTileChance is the probability that a spawned tile is a 4 instead of a 2
+	fourTileChance = 0.1
+)
+
+// Direction is a move direction
+type Direction string
+
+const (
+	DirectionUp    Direction = "up"
+	DirectionDown  Direction = "down"
+	DirectionLeft  Direction = "left"
+	DirectionRight Direction = "right"
+)
+
+// Board is the 4x4 tile grid; zero means an empty cell
+type Board [boardSize][boardSize]int
+
+// snapshot stores the state before the last move for undo. It includes the
+// spawn seed, so repeating an undone move spawns the same tile again.
+type snapshot struct {
+	Board       Board
+	Score       int64
+	Moves       int
+	SpawnSeed   int64
+	ReachedGoal bool
+}
+
+// GameState represents a 2048 game session
+type GameState struct {
+	Board       Board
+	Score       int64
+	Moves       int
+	Seed        int64
+	ReachedGoal bool
+	Locale      i18n.SupportedLocale
+	previous    *snapshot // Only the last move can be undone
+	spawnSeed   int64     // Seeds the next tile spawn, which draws the seed after it
+}
+
+// Manager manages active 2048 game sessions
+type Manager struct {
+	games map[string]*GameState // userID -> GameState
+	mu    sync.RWMutex
+}
+
+var instance *Manager
+var once sync.Once
+
+// GetManager returns the singleton game manager
+func GetManager() *Manager {
+	once.Do(func() {
+		instance = &Manager{
+			games: make(map[string]*GameState),
+		}
+	})
+	return instance
+}
+
+// StartGame starts a new game for a user. If seed is zero a time-based seed is used.
+func (m *Manager) StartGame(userID string, seed int64, locale i18n.SupportedLocale) *GameState {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	game := NewGame(seed, locale)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.games[userID] = game
+	return game
+}
+
+// GetGame retrieves an active game for a user
+func (m *Manager) GetGame(userID string) (*GameState, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	game, exists := m.games[userID]
+	return game, exists
+}
+
+// EndGame removes a game session
+func (m *Manager) EndGame(userID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.games, userID)
+}
+
+// ActiveGames returns the number of games in progress
+func (m *Manager) ActiveGames() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.games)
+}
+
+// NewGame creates a board with two starting tiles. The same seed always
+// produces the same tile spawns for the same sequence of moves.
+func NewGame(seed int64, locale i18n.SupportedLocale) *GameState {
+	game := &GameState{
+		Seed:      seed,
+		Locale:    locale,
+		spawnSeed: seed,
+	}
+	game.spawnTile()
+	game.spawnTile()
+	return game
+}
+
+// Move slides and merges the tiles in a direction, then spawns a new tile.
+// It returns false if the move did not change the board.
+func (g *GameState) Move(dir Direction) bool {
+	before := snapshot{
+		Board:       g.Board,
+		Score:       g.Score,
+		Moves:       g.Moves,
+		SpawnSeed:   g.spawnSeed,
+		ReachedGoal: g.ReachedGoal,
+	}
+
+	changed := false
+	for line := 0; line < boardSize; line++ {
+		cells := g.lineCells(dir, line)
+
+		values := make([]int, boardSize)
+		for idx, cell := range cells {
+			values[idx] = g.Board[cell[0]][cell[1]]
+		}
+
+		merged, gained := slideLine(values)
+		for idx, cell := range cells {
+			if g.Board[cell[0]][cell[1]] != merged[idx] {
+				changed = true
+			}
+			g.Board[cell[0]][cell[1]] = merged[idx]
+		}
+		g.Score += gained
+	}
+
+	if !changed {
+		return false
+	}
+
+	g.previous = &before
+	g.Moves++
+	g.spawnTile()
+
+	if g.MaxTile() >= goalTile {
+		g.ReachedGoal = true
+	}
+	return true
+}
+
+// CanUndo reports whether the last move can be undone
+func (g *GameState) CanUndo() bool {
+	return g.previous != nil
+}
+
+// Undo restores the state before the last move. Only one move can be undone.
+func (g *GameState) Undo() bool {
+	if g.previous == nil {
+		return false
+	}
+	g.Board = g.previous.Board
+	g.Score = g.previous.Score
+	g.Moves = g.previous.Moves
+	g.spawnSeed = g.previous.SpawnSeed
+	g.ReachedGoal = g.previous.ReachedGoal
+	g.previous = nil
+	return true
+}
+
+// IsOver reports whether no move is possible
+func (g *GameState) IsOver() bool {
+	for r := 0; r < boardSize; r++ {
+		for c := 0; c < boardSize; c++ {
+			value := g.Board[r][c]
+			if value == 0 {
+				return false
+			}
+			if c+1 < boardSize && g.Board[r][c+1] == value {
+				return false
+			}
+			if r+1 < boardSize && g.Board[r+1][c] == value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MaxTile returns the highest tile on the board
+func (g *GameState) MaxTile() int {
+	highest := 0
+	for r := range g.Board {
+		for c := range g.Board[r] {
+			if g.Board[r][c] > highest {
+				highest = g.Board[r][c]
+			}
+		}
+	}
+	return highest
+}
+
+// lineCells returns the board coordinates of a row or column, ordered so that
+// tiles slide towards the first cell
+func (g *GameState) lineCells(dir Direction, line int) [][2]int {
+	cells := make([][2]int, boardSize)
+	for idx := 0; idx < boardSize; idx++ {
+		switch dir {
+		case DirectionLeft:
+			cells[idx] = [2]int{line, idx}
+		case DirectionRight:
+			cells[idx] = [2]int{line, boardSize - 1 - idx}
+		case DirectionUp:
+			cells[idx] = [2]int{idx, line}
+		case DirectionDown:
+			cells[idx] = [2]int{boardSize - 1 - idx, line}
+		}
+	}
+	return cells
+}
+
+// slideLine slides the values towards index zero, merging equal neighbours once,
+// and returns the new line with the points gained
+func slideLine(values []int) ([]int, int64) {
+	result := make([]int, 0, len(values))
+	var gained int64
+
+	for _, value := range values {
+		if value == 0 {
+			continue
+		}
+		last := len(result) - 1
+		if last >= 0 && result[last] == value {
+			result[last] *= 2
+			gained += int64(result[last])
+			// Mark the merged tile so it cannot merge again in this move
+			result = append(result, 0)
+			continue
+		}
+		result = append(result, value)
+	}
+
+	line := make([]int, len(values))
+	idx := 0
+	for _, value := range result {
+		if value != 0 {
+			line[idx] = value
+			idx++
+		}
+	}
+	return line, gained
+}
+
+// spawnTile places a 2 (or sometimes a 4) on a random empty cell
+func (g *GameState) spawnTile() {
+	var empty [][2]int
+	for r := 0; r < boardSize; r++ {
+		for c := 0; c < boardSize; c++ {
+			if g.Board[r][c] == 0 {
+				empty = append(empty, [2]int{r, c})
+			}
+		}
+	}
+	if len(empty) == 0 {
+		return
+	}
+
+	rng := rand.New(rand.NewSource(g.spawnSeed))
+	cell := empty[rng.Intn(len(empty))]
+	value := 2
+	if rng.Float64() < fourTileChance {
+		value = 4
+	}
+	g.Board[cell[0]][cell[1]] = value
+	g.spawnSeed = rng.Int63()
+}
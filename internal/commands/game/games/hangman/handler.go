@@ -0,0 +1,274 @@
+package hangman
+
+import (
+	"fmt"
+	"strings"
+
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// maxSelectOptions is the maximum number of options Discord allows in a select menu
+	maxSelectOptions = 25
+)
+
+// gallowsStages holds the ASCII gallows for 0..maxWrongGuesses wrong guesses
+var gallowsStages = []string{
+	"  +---+\n  |   |\n      |\n      |\n      |\n      |\n=========",
+	"  +---+\n  |   |\n  O   |\n      |\n      |\n      |\n=========",
+	"  +---+\n  |   |\n  O   |\n  |   |\n      |\n      |\n=========",
+	"  +---+\n  |   |\n  O   |\n /|   |\n      |\n      |\n=========",
+	"  +---+\n  |   |\n  O   |\n /|\\  |\n      |\n      |\n=========",
+	"  +---+\n  |   |\n  O   |\n /|\\  |\n /    |\n      |\n=========",
+	"  +---+\n  |   |\n  O   |\n /|\\  |\n / \\  |\n      |\n=========",
+}
+
+// HandleStart starts a new Hangman game
+func HandleStart(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	manager := GetManager()
+
+	userID := interactions.UserID(i)
+	if userID == "" {
+		return fmt.Errorf("could not get user ID")
+	}
+
+	if _, exists := manager.GetGame(userID); exists {
+		return interactions.RespondError(s, i, locale, "game.hangman.error.already_active", true)
+	}
+
+	// Default to the word pack matching the user's language
+	packID := string(locale)
+	category := ""
+	options := i.ApplicationCommandData().Options
+	if len(options) > 0 {
+		for _, opt := range options[0].Options {
+			switch opt.Name {
+			case "pack":
+				packID = opt.StringValue()
+			case "category":
+				category = opt.StringValue()
+			}
+		}
+	}
+
+	pack, exists := GetWordPack(packID)
+	if !exists {
+		pack, exists = GetWordPack(string(i18n.LocaleEnUS))
+		if !exists {
+			return interactions.RespondError(s, i, locale, "game.hangman.error.no_words", true)
+		}
+	}
+
+	game, ok := manager.StartGame(userID, pack, category, locale)
+	if !ok {
+		return interactions.RespondError(s, i, locale, "game.hangman.error.no_words", true)
+	}
+
+	message := buildGameMessage(game, false)
+	message.Components = buildGameComponents(userID, game)
+
+	return interactions.RespondCustom(s, i, message)
+}
+
+// HandleComponent handles letter selection and give up interactions
+func HandleComponent(s *discordgo.Session, i *discordgo.InteractionCreate, action string) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	manager := GetManager()
+
+	userID := interactions.UserID(i)
+	if userID == "" {
+		return fmt.Errorf("could not get user ID")
+	}
+
+	game, exists := manager.GetGame(userID)
+	if !exists {
+		return interactions.RespondError(s, i, locale, "game.hangman.error.no_active_game", true)
+	}
+
+	switch action {
+	case "pick":
+		values := i.MessageComponentData().Values
+		if len(values) == 0 {
+			return nil
+		}
+
+		letter := []rune(values[0])[0]
+		if _, accepted := game.Guess(letter); !accepted {
+			return interactions.RespondError(s, i, locale, "game.hangman.error.already_guessed", true, values[0])
+		}
+
+		var message *discordgo.InteractionResponseData
+		switch {
+		case game.IsWon():
+			manager.EndGame(userID)
+			message = buildGameMessage(game, true)
+			message.Content += "\n\n" + i18n.Tf(game.Locale, "game.hangman.result.won", len(game.Wrong))
+			message.Components = []discordgo.MessageComponent{} // Remove components
+		case game.IsLost():
+			manager.EndGame(userID)
+			message = buildGameMessage(game, true)
+			message.Content += "\n\n" + i18n.T(game.Locale, "game.hangman.result.lost")
+			message.Components = []discordgo.MessageComponent{} // Remove components
+		default:
+			message = buildGameMessage(game, false)
+			message.Components = buildGameComponents(userID, game)
+		}
+
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: message,
+		})
+
+	case "giveup":
+		manager.EndGame(userID)
+
+		message := buildGameMessage(game, true)
+		message.Content += "\n\n" + i18n.T(game.Locale, "game.hangman.result.giveup")
+		message.Components = []discordgo.MessageComponent{} // Remove components
+
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: message,
+		})
+
+	default:
+		return interactions.RespondError(s, i, locale, "command.unknown", true)
+	}
+}
+
+// buildGameMessage builds the game status message
+func buildGameMessage(game *GameState, gameOver bool) *discordgo.InteractionResponseData {
+	locale := game.Locale
+	var builder strings.Builder
+
+	packName := game.Pack
+	if pack, exists := GetWordPack(game.Pack); exists {
+		packName = pack.Name
+	}
+	categoryName := i18n.T(locale, "game.hangman.category."+game.Category)
+
+	builder.WriteString(i18n.Tf(locale, "game.hangman.title_with_category", packName, categoryName))
+	builder.WriteString("\n\n")
+
+	// Gallows
+	builder.WriteString("```\n")
+	builder.WriteString(gallowsStages[len(game.Wrong)])
+	builder.WriteString("\n```\n")
+
+	// Word progress
+	builder.WriteString(i18n.Tf(locale, "game.hangman.word", formatWord(game, gameOver)))
+	builder.WriteString("\n")
+
+	// Wrong guesses
+	wrong := i18n.T(locale, "game.hangman.none")
+	if len(game.Wrong) > 0 {
+		letters := make([]string, len(game.Wrong))
+		for idx, r := range game.Wrong {
+			letters[idx] = string(r)
+		}
+		wrong = strings.Join(letters, " ")
+	}
+	builder.WriteString(i18n.Tf(locale, "game.hangman.wrong_guesses", len(game.Wrong), maxWrongGuesses, wrong))
+
+	if gameOver {
+		builder.WriteString("\n" + i18n.Tf(locale, "game.hangman.answer", string(game.Word)))
+		if game.Hint != "" {
+			builder.WriteString("\n" + i18n.Tf(locale, "game.hangman.hint", game.Hint))
+		}
+	}
+
+	return &discordgo.InteractionResponseData{
+		Content: builder.String(),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	}
+}
+
+// formatWord renders the word with unrevealed letters masked
+func formatWord(game *GameState, reveal bool) string {
+	cells := make([]string, 0, len(game.Word))
+	for _, r := range game.Word {
+		switch {
+		case r == ' ':
+			cells = append(cells, " ")
+		case reveal || !game.IsGuessable(r) || game.Guessed[r]:
+			cells = append(cells, string(r))
+		default:
+			cells = append(cells, "＿")
+		}
+	}
+	return "`" + strings.Join(cells, " ") + "`"
+}
+
+// buildGameComponents creates the letter picker menus and the give up button.
+// The alphabet is split evenly across as many select menus as needed, one per row.
+func buildGameComponents(userID string, game *GameState) []discordgo.MessageComponent {
+	locale := game.Locale
+	var rows []discordgo.MessageComponent
+
+	for idx, chunk := range splitAlphabet(game.Alphabet) {
+		var options []discordgo.SelectMenuOption
+		for _, letter := range chunk {
+			if game.Guessed[[]rune(letter)[0]] {
+				continue
+			}
+			options = append(options, discordgo.SelectMenuOption{
+				Label: letter,
+				Value: letter,
+			})
+		}
+
+		// Discord rejects empty select menus
+		if len(options) == 0 {
+			continue
+		}
+
+		rows = append(rows, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    fmt.Sprintf("game_hangman_pick_%d_%s", idx, userID),
+					Placeholder: i18n.Tf(locale, "game.hangman.pick_placeholder", chunk[0], chunk[len(chunk)-1]),
+					Options:     options,
+				},
+			},
+		})
+	}
+
+	rows = append(rows, discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    i18n.T(locale, "game.hangman.button.giveup"),
+				Style:    discordgo.DangerButton,
+				CustomID: fmt.Sprintf("game_hangman_giveup_%s", userID),
+				Emoji: &discordgo.ComponentEmoji{
+					Name: "🏳️",
+				},
+			},
+		},
+	})
+
+	return rows
+}
+
+// splitAlphabet splits the alphabet into evenly sized chunks that fit in a select menu
+func splitAlphabet(alphabet []string) [][]string {
+	if len(alphabet) == 0 {
+		return nil
+	}
+
+	count := (len(alphabet) + maxSelectOptions - 1) / maxSelectOptions
+	size := (len(alphabet) + count - 1) / count
+
+	chunks := make([][]string, 0, count)
+	for start := 0; start < len(alphabet); start += size {
+		end := start + size
+		if end > len(alphabet) {
+			end = len(alphabet)
+		}
+		chunks = append(chunks, alphabet[start:end])
+	}
+	return chunks
+}
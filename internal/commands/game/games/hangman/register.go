@@ -0,0 +1,94 @@
+package hangman
+
+import (
+	"hiei-discord-bot/internal/commands/game"
+	"hiei-discord-bot/internal/interactions"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func init() {
+	router := interactions.GetRouter()
+
+	// Register component handler (letter menus and buttons)
+	router.RegisterComponent("game_hangman_", handleComponentInteraction)
+
+	// Register as game subcommand
+	game.RegisterSubCommand(&SubCommand{})
+}
+
+// SubCommand implements game.SubCommand interface
+type SubCommand struct{}
+
+func (s *SubCommand) Name() string {
+	return "hangman"
+}
+
+func (s *SubCommand) Description() string {
+	return "Play Hangman with multilingual word packs"
+}
+
+func (s *SubCommand) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "category",
+			Description: "Word category (default: random)",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{
+					Name:  "Animals",
+					Value: "animals",
+				},
+				{
+					Name:  "Food",
+					Value: "food",
+				},
+				{
+					Name:  "Countries",
+					Value: "countries",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "pack",
+			Description: "Word pack language (default: your language)",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{
+					Name:  "English",
+					Value: "en-US",
+				},
+				{
+					Name:  "注音 (Zhuyin)",
+					Value: "zh-TW",
+				},
+			},
+		},
+	}
+}
+
+func (s *SubCommand) Handle(session *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return HandleStart(session, i)
+}
+
+// handleComponentInteraction routes component interactions to the appropriate handler
+func handleComponentInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	customID := i.MessageComponentData().CustomID
+
+	// Extract action from customID: game_hangman_{action}_..._{userID}
+	parts := strings.Split(customID, "_")
+	if len(parts) < 4 {
+		return nil
+	}
+
+	action := parts[2] // "pick" or "giveup"
+	return HandleComponent(s, i, action)
+}
+
+// ActiveSessions returns the number of games in progress
+func (s *SubCommand) ActiveSessions() int {
+	return GetManager().ActiveGames()
+}
@@ -0,0 +1,149 @@
+package hangman
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"hiei-discord-bot/internal/i18n"
+)
+
+const (
+	maxWrongGuesses = 6
+)
+
+// GameState represents a Hangman game session
+type GameState struct {
+	Pack     string               // Word pack ID (e.g. "en-US", "zh-TW")
+	Category string               // Category key within the pack
+	Word     []rune               // The secret word, including separators and tone marks
+	Hint     string               // Optional hint revealed at the end (e.g. the Chinese characters)
+	Guessed  map[rune]bool        // Letters guessed so far
+	Wrong    []rune               // Wrong guesses in order
+	Alphabet []string             // Letters that can be picked
+	Locale   i18n.SupportedLocale // User's language preference
+}
+
+// Manager manages active Hangman game sessions
+type Manager struct {
+	games map[string]*GameState // userID -> GameState
+	mu    sync.RWMutex
+}
+
+var instance *Manager
+var once sync.Once
+
+// GetManager returns the singleton game manager
+func GetManager() *Manager {
+	once.Do(func() {
+		instance = &Manager{
+			games: make(map[string]*GameState),
+		}
+	})
+	return instance
+}
+
+// StartGame starts a new game for a user with a random word from the given pack and category.
+// If category is empty, a random category is chosen.
+func (m *Manager) StartGame(userID string, pack *WordPack, category string, locale i18n.SupportedLocale) (*GameState, bool) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	if category == "" {
+		keys := pack.CategoryKeys()
+		if len(keys) == 0 {
+			return nil, false
+		}
+		category = keys[rng.Intn(len(keys))]
+	}
+
+	words := pack.Categories[category]
+	if len(words) == 0 {
+		return nil, false
+	}
+	entry := words[rng.Intn(len(words))]
+
+	state := &GameState{
+		Pack:     pack.ID,
+		Category: category,
+		Word:     []rune(entry.Word),
+		Hint:     entry.Hint,
+		Guessed:  make(map[rune]bool),
+		Wrong:    make([]rune, 0, maxWrongGuesses),
+		Alphabet: pack.Alphabet,
+		Locale:   locale,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.games[userID] = state
+	return state, true
+}
+
+// GetGame retrieves an active game for a user
+func (m *Manager) GetGame(userID string) (*GameState, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	game, exists := m.games[userID]
+	return game, exists
+}
+
+// EndGame removes a game session
+func (m *Manager) EndGame(userID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.games, userID)
+}
+
+// ActiveGames returns the number of games in progress
+func (m *Manager) ActiveGames() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.games)
+}
+
+// Guess records a guessed letter and reports whether it was in the word.
+// The second return value is false if the letter was already guessed.
+func (g *GameState) Guess(letter rune) (hit bool, accepted bool) {
+	if g.Guessed[letter] {
+		return false, false
+	}
+	g.Guessed[letter] = true
+
+	for _, r := range g.Word {
+		if r == letter {
+			return true, true
+		}
+	}
+
+	g.Wrong = append(g.Wrong, letter)
+	return false, true
+}
+
+// IsGuessable reports whether a rune of the word must be guessed by the player.
+// Spaces, tone marks and other characters outside the alphabet are revealed from the start.
+func (g *GameState) IsGuessable(r rune) bool {
+	for _, letter := range g.Alphabet {
+		if []rune(letter)[0] == r {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWon checks if every guessable letter has been revealed
+func (g *GameState) IsWon() bool {
+	for _, r := range g.Word {
+		if g.IsGuessable(r) && !g.Guessed[r] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsLost checks if the player has run out of wrong guesses
+func (g *GameState) IsLost() bool {
+	return len(g.Wrong) >= maxWrongGuesses
+}
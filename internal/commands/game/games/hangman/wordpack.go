@@ -0,0 +1,100 @@
+package hangman
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"hiei-discord-bot/resources"
+)
+
+// WordEntry is a single word in a word pack
+type WordEntry struct {
+	Word string `json:"word"`
+	Hint string `json:"hint,omitempty"`
+}
+
+// WordPack is a set of categorized words sharing one alphabet
+type WordPack struct {
+	ID         string                 `json:"-"`
+	Name       string                 `json:"name"`
+	Alphabet   []string               `json:"alphabet"`
+	Categories map[string][]WordEntry `json:"categories"`
+}
+
+var (
+	packs     map[string]*WordPack
+	packsOnce sync.Once
+)
+
+// GetWordPacks returns all embedded word packs keyed by pack ID (file name without extension)
+func GetWordPacks() map[string]*WordPack {
+	packsOnce.Do(func() {
+		loaded, err := loadWordPacks()
+		if err != nil {
+			slog.Error("Failed to load hangman word packs", "error", err)
+		}
+		packs = loaded
+	})
+	return packs
+}
+
+// GetWordPack retrieves a word pack by ID
+func GetWordPack(id string) (*WordPack, bool) {
+	pack, exists := GetWordPacks()[id]
+	return pack, exists
+}
+
+// CategoryKeys returns the sorted category keys of the pack
+func (p *WordPack) CategoryKeys() []string {
+	keys := make([]string, 0, len(p.Categories))
+	for key := range p.Categories {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// loadWordPacks reads and validates every JSON word pack in the embedded filesystem
+func loadWordPacks() (map[string]*WordPack, error) {
+	result := make(map[string]*WordPack)
+
+	entries, err := fs.ReadDir(resources.HangmanWordPacks, resources.HangmanBasePath)
+	if err != nil {
+		return result, fmt.Errorf("failed to list word packs: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		filename := path.Join(resources.HangmanBasePath, entry.Name())
+		data, err := resources.HangmanWordPacks.ReadFile(filename)
+		if err != nil {
+			return result, fmt.Errorf("failed to read word pack %s: %w", filename, err)
+		}
+
+		var pack WordPack
+		if err := json.Unmarshal(data, &pack); err != nil {
+			return result, fmt.Errorf("failed to parse word pack %s: %w", filename, err)
+		}
+
+		pack.ID = strings.TrimSuffix(entry.Name(), ".json")
+		for i, letter := range pack.Alphabet {
+			if len([]rune(letter)) != 1 {
+				return result, fmt.Errorf("word pack %s: alphabet entry %d (%q) must be a single character", pack.ID, i, letter)
+			}
+		}
+
+		result[pack.ID] = &pack
+		slog.Info("Loaded hangman word pack", "pack", pack.ID, "categories", len(pack.Categories))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,235 @@
+package minesweeper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// numberLabels holds the button label for each adjacent mine count
+var numberLabels = []string{"\u200b", "1", "2", "3", "4", "5", "6", "7", "8"}
+
+// HandleStart starts a new Minesweeper game
+func HandleStart(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	manager := GetManager()
+
+	userID := interactions.UserID(i)
+	if userID == "" {
+		return fmt.Errorf("could not get user ID")
+	}
+
+	if _, exists := manager.GetGame(userID); exists {
+		return interactions.RespondError(s, i, locale, "game.minesweeper.error.already_active", true)
+	}
+
+	difficulty := DifficultyEasy
+	options := i.ApplicationCommandData().Options
+	if len(options) > 0 {
+		for _, opt := range options[0].Options {
+			if opt.Name == "difficulty" {
+				difficulty = Difficulty(opt.StringValue())
+			}
+		}
+	}
+
+	game := manager.StartGame(userID, difficulty, locale)
+
+	return interactions.RespondCustom(s, i, buildGameMessage(userID, game, false, ""))
+}
+
+// HandleButtonClick handles cell, mode toggle and give up buttons
+func HandleButtonClick(s *discordgo.Session, i *discordgo.InteractionCreate, action string, args []string) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	manager := GetManager()
+
+	userID := interactions.UserID(i)
+	if userID == "" {
+		return fmt.Errorf("could not get user ID")
+	}
+
+	game, exists := manager.GetGame(userID)
+	if !exists {
+		return interactions.RespondError(s, i, locale, "game.minesweeper.error.no_active_game", true)
+	}
+
+	var message *discordgo.InteractionResponseData
+
+	switch action {
+	case "cell":
+		if len(args) != 2 {
+			return interactions.RespondError(s, i, locale, "command.unknown", true)
+		}
+		row, errRow := strconv.Atoi(args[0])
+		col, errCol := strconv.Atoi(args[1])
+		if errRow != nil || errCol != nil || row < 0 || row >= boardRows || col < 0 || col >= boardCols {
+			return interactions.RespondError(s, i, locale, "command.unknown", true)
+		}
+
+		if game.FlagMode {
+			game.ToggleFlag(row, col)
+		} else {
+			game.Reveal(row, col)
+		}
+
+		switch {
+		case game.IsLost():
+			manager.EndGame(userID)
+			result := i18n.Tf(game.Locale, "game.minesweeper.result.lost", formatDuration(time.Since(game.StartedAt)))
+			message = buildGameMessage(userID, game, true, result)
+		case game.IsWon():
+			manager.EndGame(userID)
+			result := i18n.Tf(game.Locale, "game.minesweeper.result.won", formatDuration(time.Since(game.StartedAt)))
+			message = buildGameMessage(userID, game, true, result)
+		default:
+			message = buildGameMessage(userID, game, false, "")
+		}
+
+	case "mode":
+		game.FlagMode = !game.FlagMode
+		message = buildGameMessage(userID, game, false, "")
+
+	case "giveup":
+		manager.EndGame(userID)
+		message = buildGameMessage(userID, game, true, i18n.T(game.Locale, "game.minesweeper.result.giveup"))
+
+	default:
+		return interactions.RespondError(s, i, locale, "command.unknown", true)
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: message,
+	})
+}
+
+// buildGameMessage builds the game status message with the board. A full 5×5
+// board and the control row make six action rows, more than a classic message
+// may hold, so the message uses components V2 with the status as a text display.
+func buildGameMessage(userID string, game *GameState, gameOver bool, result string) *discordgo.InteractionResponseData {
+	locale := game.Locale
+	var builder strings.Builder
+
+	difficultyName := i18n.T(locale, "game.minesweeper.difficulty."+string(game.Difficulty))
+	builder.WriteString(i18n.Tf(locale, "game.minesweeper.title_with_difficulty", difficultyName))
+	builder.WriteString("\n\n")
+
+	builder.WriteString(i18n.Tf(locale, "game.minesweeper.mines", game.Mines, game.FlagCount()))
+	builder.WriteString("\n")
+
+	if !gameOver {
+		builder.WriteString(i18n.Tf(locale, "game.minesweeper.started", game.StartedAt.Unix()))
+		builder.WriteString("\n")
+
+		modeKey := "game.minesweeper.mode.reveal"
+		if game.FlagMode {
+			modeKey = "game.minesweeper.mode.flag"
+		}
+		builder.WriteString(i18n.Tf(locale, "game.minesweeper.current_mode", i18n.T(locale, modeKey)))
+	}
+
+	if result != "" {
+		builder.WriteString("\n\n")
+		builder.WriteString(result)
+	}
+
+	components := []discordgo.MessageComponent{discordgo.TextDisplay{Content: builder.String()}}
+	return &discordgo.InteractionResponseData{
+		Components: append(components, buildGameComponents(userID, game, gameOver)...),
+		Flags:      discordgo.MessageFlagsEphemeral | discordgo.MessageFlagsIsComponentsV2,
+	}
+}
+
+// buildGameComponents creates the board buttons and the control row.
+// When gameOver is true every mine is shown and all buttons are disabled.
+func buildGameComponents(userID string, game *GameState, gameOver bool) []discordgo.MessageComponent {
+	locale := game.Locale
+	rows := make([]discordgo.MessageComponent, 0, boardRows+1)
+
+	for r := 0; r < boardRows; r++ {
+		buttons := make([]discordgo.MessageComponent, 0, boardCols)
+		for c := 0; c < boardCols; c++ {
+			button := buildCellButton(game, r, c, gameOver)
+			button.CustomID = fmt.Sprintf("game_minesweeper_cell_%d_%d_%s", r, c, userID)
+			buttons = append(buttons, button)
+		}
+		rows = append(rows, discordgo.ActionsRow{Components: buttons})
+	}
+
+	if gameOver {
+		return rows
+	}
+
+	modeLabel := i18n.T(locale, "game.minesweeper.button.mode_reveal")
+	modeEmoji := "⛏️"
+	modeStyle := discordgo.PrimaryButton
+	if game.FlagMode {
+		modeLabel = i18n.T(locale, "game.minesweeper.button.mode_flag")
+		modeEmoji = "🚩"
+		modeStyle = discordgo.SuccessButton
+	}
+
+	rows = append(rows, discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    modeLabel,
+				Style:    modeStyle,
+				CustomID: fmt.Sprintf("game_minesweeper_mode_%s", userID),
+				Emoji: &discordgo.ComponentEmoji{
+					Name: modeEmoji,
+				},
+			},
+			discordgo.Button{
+				Label:    i18n.T(locale, "game.minesweeper.button.giveup"),
+				Style:    discordgo.DangerButton,
+				CustomID: fmt.Sprintf("game_minesweeper_giveup_%s", userID),
+				Emoji: &discordgo.ComponentEmoji{
+					Name: "🏳️",
+				},
+			},
+		},
+	})
+
+	return rows
+}
+
+// buildCellButton renders a single board cell as a button
+func buildCellButton(game *GameState, row, col int, gameOver bool) discordgo.Button {
+	cell := game.Board[row][col]
+	button := discordgo.Button{
+		Label:    "\u200b",
+		Style:    discordgo.SecondaryButton,
+		Disabled: gameOver,
+	}
+
+	switch {
+	case cell.Mine && (cell.Revealed || gameOver):
+		button.Label = ""
+		button.Emoji = &discordgo.ComponentEmoji{Name: "💣"}
+		if game.Exploded && game.ExplodedAt == [2]int{row, col} {
+			button.Emoji = &discordgo.ComponentEmoji{Name: "💥"}
+			button.Style = discordgo.DangerButton
+		}
+	case cell.Flagged:
+		button.Label = ""
+		button.Emoji = &discordgo.ComponentEmoji{Name: "🚩"}
+	case cell.Revealed:
+		button.Label = numberLabels[cell.Adjacent]
+		button.Style = discordgo.SuccessButton
+		button.Disabled = true
+	}
+
+	return button
+}
+
+// formatDuration formats an elapsed duration as m:ss
+func formatDuration(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
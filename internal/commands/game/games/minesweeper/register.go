@@ -0,0 +1,78 @@
+package minesweeper
+
+import (
+	"hiei-discord-bot/internal/commands/game"
+	"hiei-discord-bot/internal/interactions"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func init() {
+	router := interactions.GetRouter()
+
+	// Register component handler (buttons)
+	router.RegisterComponent("game_minesweeper_", handleComponentInteraction)
+
+	// Register as game subcommand
+	game.RegisterSubCommand(&SubCommand{})
+}
+
+// SubCommand implements game.SubCommand interface
+type SubCommand struct{}
+
+func (s *SubCommand) Name() string {
+	return "minesweeper"
+}
+
+func (s *SubCommand) Description() string {
+	return "Play Minesweeper on a button grid"
+}
+
+func (s *SubCommand) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "difficulty",
+			Description: "Game difficulty (default: easy)",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{
+					Name:  "Easy (3 mines)",
+					Value: string(DifficultyEasy),
+				},
+				{
+					Name:  "Medium (5 mines)",
+					Value: string(DifficultyMedium),
+				},
+				{
+					Name:  "Hard (7 mines)",
+					Value: string(DifficultyHard),
+				},
+			},
+		},
+	}
+}
+
+func (s *SubCommand) Handle(session *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return HandleStart(session, i)
+}
+
+// handleComponentInteraction routes button interactions to the appropriate handler
+func handleComponentInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	customID := i.MessageComponentData().CustomID
+
+	// Extract action from customID: game_minesweeper_{action}_..._{userID}
+	parts := strings.Split(customID, "_")
+	if len(parts) < 4 {
+		return nil
+	}
+
+	action := parts[2] // "cell", "mode" or "giveup"
+	return HandleButtonClick(s, i, action, parts[3:len(parts)-1])
+}
+
+// ActiveSessions returns the number of games in progress
+func (s *SubCommand) ActiveSessions() int {
+	return GetManager().ActiveGames()
+}
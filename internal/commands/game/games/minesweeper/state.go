@@ -0,0 +1,284 @@
+package minesweeper
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"hiei-discord-bot/internal/i18n"
+)
+
+const (
+	// An action row holds at most 5 buttons. The control buttons take a sixth
+	// row below the board, see buildGameMessage.
+	boardRows = 5
+	boardCols = 5
+)
+
+// Difficulty represents the game difficulty level
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+)
+
+// mineCounts maps each difficulty to the number of mines on the board
+var mineCounts = map[Difficulty]int{
+	DifficultyEasy:   3,
+	DifficultyMedium: 5,
+	DifficultyHard:   7,
+}
+
+// Cell represents a single board cell
+type Cell struct {
+	Mine     bool
+	Revealed bool
+	Flagged  bool
+	Adjacent int // Number of adjacent mines
+}
+
+// GameState represents a Minesweeper game session
+type GameState struct {
+	Board       [boardRows][boardCols]Cell
+	Difficulty  Difficulty
+	Mines       int
+	FlagMode    bool // When true, clicking a cell toggles a flag instead of revealing it
+	MinesPlaced bool // Mines are placed on the first reveal to guarantee a safe start
+	Exploded    bool
+	ExplodedAt  [2]int
+	StartedAt   time.Time
+	Locale      i18n.SupportedLocale // User's language preference
+	rng         *rand.Rand
+}
+
+// Manager manages active Minesweeper game sessions
+type Manager struct {
+	games map[string]*GameState // userID -> GameState
+	mu    sync.RWMutex
+}
+
+var instance *Manager
+var once sync.Once
+
+// GetManager returns the singleton game manager
+func GetManager() *Manager {
+	once.Do(func() {
+		instance = &Manager{
+			games: make(map[string]*GameState),
+		}
+	})
+	return instance
+}
+
+// StartGame starts a new game for a user
+func (m *Manager) StartGame(userID string, difficulty Difficulty, locale i18n.SupportedLocale) *GameState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mines, ok := mineCounts[difficulty]
+	if !ok {
+		difficulty = DifficultyEasy
+		mines = mineCounts[DifficultyEasy]
+	}
+
+	state := &GameState{
+		Difficulty: difficulty,
+		Mines:      mines,
+		StartedAt:  time.Now(),
+		Locale:     locale,
+		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	m.games[userID] = state
+	return state
+}
+
+// GetGame retrieves an active game for a user
+func (m *Manager) GetGame(userID string) (*GameState, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	game, exists := m.games[userID]
+	return game, exists
+}
+
+// EndGame removes a game session
+func (m *Manager) EndGame(userID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.games, userID)
+}
+
+// ActiveGames returns the number of games in progress
+func (m *Manager) ActiveGames() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.games)
+}
+
+// Reveal reveals a cell and returns true if a mine was hit.
+// Revealing a cell without adjacent mines flood-fills its neighbours.
+func (g *GameState) Reveal(row, col int) bool {
+	cell := &g.Board[row][col]
+	if cell.Revealed || cell.Flagged {
+		return false
+	}
+
+	if !g.MinesPlaced {
+		g.placeMines(row, col)
+	}
+
+	if cell.Mine {
+		cell.Revealed = true
+		g.Exploded = true
+		g.ExplodedAt = [2]int{row, col}
+		return true
+	}
+
+	g.floodFill(row, col)
+	return false
+}
+
+// ToggleFlag toggles the flag on an unrevealed cell
+func (g *GameState) ToggleFlag(row, col int) {
+	cell := &g.Board[row][col]
+	if cell.Revealed {
+		return
+	}
+	cell.Flagged = !cell.Flagged
+}
+
+// FlagCount returns the number of flagged cells
+func (g *GameState) FlagCount() int {
+	count := 0
+	for r := range g.Board {
+		for c := range g.Board[r] {
+			if g.Board[r][c].Flagged {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// IsWon checks if every safe cell has been revealed
+func (g *GameState) IsWon() bool {
+	if !g.MinesPlaced || g.Exploded {
+		return false
+	}
+	for r := range g.Board {
+		for c := range g.Board[r] {
+			cell := g.Board[r][c]
+			if !cell.Mine && !cell.Revealed {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IsLost checks if a mine has been revealed
+func (g *GameState) IsLost() bool {
+	return g.Exploded
+}
+
+// placeMines randomly places mines, keeping the first revealed cell and, when
+// the board has room, its neighbours free of mines
+func (g *GameState) placeMines(safeRow, safeCol int) {
+	var candidates, fallback [][2]int
+	for r := 0; r < boardRows; r++ {
+		for c := 0; c < boardCols; c++ {
+			if r == safeRow && c == safeCol {
+				continue
+			}
+			if abs(r-safeRow) <= 1 && abs(c-safeCol) <= 1 {
+				fallback = append(fallback, [2]int{r, c})
+				continue
+			}
+			candidates = append(candidates, [2]int{r, c})
+		}
+	}
+
+	g.rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	g.rng.Shuffle(len(fallback), func(i, j int) {
+		fallback[i], fallback[j] = fallback[j], fallback[i]
+	})
+
+	// Use neighbours of the first cell only if the rest of the board is too small
+	positions := append(candidates, fallback...)
+	for _, pos := range positions[:g.Mines] {
+		g.Board[pos[0]][pos[1]].Mine = true
+	}
+
+	for r := 0; r < boardRows; r++ {
+		for c := 0; c < boardCols; c++ {
+			g.Board[r][c].Adjacent = g.countAdjacentMines(r, c)
+		}
+	}
+
+	g.MinesPlaced = true
+}
+
+// floodFill reveals the cell and recursively reveals neighbours of empty cells
+func (g *GameState) floodFill(row, col int) {
+	stack := [][2]int{{row, col}}
+	for len(stack) > 0 {
+		pos := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		cell := &g.Board[pos[0]][pos[1]]
+		if cell.Revealed || cell.Flagged || cell.Mine {
+			continue
+		}
+		cell.Revealed = true
+
+		if cell.Adjacent > 0 {
+			continue
+		}
+
+		g.forEachNeighbour(pos[0], pos[1], func(r, c int) {
+			if !g.Board[r][c].Revealed {
+				stack = append(stack, [2]int{r, c})
+			}
+		})
+	}
+}
+
+// countAdjacentMines counts mines around a cell
+func (g *GameState) countAdjacentMines(row, col int) int {
+	count := 0
+	g.forEachNeighbour(row, col, func(r, c int) {
+		if g.Board[r][c].Mine {
+			count++
+		}
+	})
+	return count
+}
+
+// forEachNeighbour calls fn for every in-bounds neighbour of a cell
+func (g *GameState) forEachNeighbour(row, col int, fn func(r, c int)) {
+	for dr := -1; dr <= 1; dr++ {
+		for dc := -1; dc <= 1; dc++ {
+			if dr == 0 && dc == 0 {
+				continue
+			}
+			r, c := row+dr, col+dc
+			if r >= 0 && r < boardRows && c >= 0 && c < boardCols {
+				fn(r, c)
+			}
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,97 @@
+package trivia
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/resources"
+)
+
+// Question is a single multiple-choice question
+type Question struct {
+	Question string   `json:"question"`
+	Choices  []string `json:"choices"`
+	Answer   int      `json:"answer"` // Index of the correct choice
+}
+
+// Category is a named group of questions
+type Category struct {
+	Name      string     `json:"name"`
+	Questions []Question `json:"questions"`
+}
+
+// Bank holds every category of a single locale
+type Bank struct {
+	Categories map[string]*Category `json:"categories"`
+}
+
+var (
+	banks     map[i18n.SupportedLocale]*Bank
+	banksOnce sync.Once
+)
+
+// GetBank returns the question bank for a locale, falling back to English
+func GetBank(locale i18n.SupportedLocale) (*Bank, bool) {
+	banksOnce.Do(func() {
+		loaded, err := loadBanks()
+		if err != nil {
+			slog.Error("Failed to load trivia question banks", "error", err)
+		}
+		banks = loaded
+	})
+
+	if bank, exists := banks[locale]; exists {
+		return bank, true
+	}
+	bank, exists := banks[i18n.LocaleEnUS]
+	return bank, exists
+}
+
+// CategoryKeys returns the sorted category keys of the bank
+func (b *Bank) CategoryKeys() []string {
+	keys := make([]string, 0, len(b.Categories))
+	for key := range b.Categories {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// loadBanks reads and validates the question bank of every supported locale
+func loadBanks() (map[i18n.SupportedLocale]*Bank, error) {
+	result := make(map[i18n.SupportedLocale]*Bank)
+
+	locales := []i18n.SupportedLocale{i18n.LocaleZhTW, i18n.LocaleEnUS}
+	for _, locale := range locales {
+		filename := fmt.Sprintf("%s/%s.json", resources.TriviaBasePath, locale)
+		data, err := resources.TriviaBanks.ReadFile(filename)
+		if err != nil {
+			return result, fmt.Errorf("failed to read question bank %s: %w", locale, err)
+		}
+
+		var bank Bank
+		if err := json.Unmarshal(data, &bank); err != nil {
+			return result, fmt.Errorf("failed to parse question bank %s: %w", locale, err)
+		}
+
+		for key, category := range bank.Categories {
+			for idx, q := range category.Questions {
+				if len(q.Choices) < 2 || len(q.Choices) > len(choiceEmojis) {
+					return result, fmt.Errorf("question bank %s: %s #%d must have 2-%d choices", locale, key, idx, len(choiceEmojis))
+				}
+				if q.Answer < 0 || q.Answer >= len(q.Choices) {
+					return result, fmt.Errorf("question bank %s: %s #%d has an out of range answer", locale, key, idx)
+				}
+			}
+		}
+
+		result[locale] = &bank
+		slog.Info("Loaded trivia question bank", "locale", locale, "categories", len(bank.Categories))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,280 @@
+package trivia
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// choiceEmojis labels the answer buttons
+var choiceEmojis = []string{"🇦", "🇧", "🇨", "🇩"}
+
+// rankEmojis decorates the top three players in the results
+var rankEmojis = []string{"🥇", "🥈", "🥉"}
+
+// HandleStart starts a trivia game in the current channel
+func HandleStart(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	manager := GetManager()
+
+	userID := interactions.UserID(i)
+	if userID == "" {
+		return fmt.Errorf("could not get user ID")
+	}
+
+	categoryKey := ""
+	rounds := defaultRounds
+	options := i.ApplicationCommandData().Options
+	if len(options) > 0 {
+		for _, opt := range options[0].Options {
+			switch opt.Name {
+			case "category":
+				categoryKey = opt.StringValue()
+			case "rounds":
+				rounds = int(opt.IntValue())
+			}
+		}
+	}
+	if rounds < 1 || rounds > maxRounds {
+		rounds = defaultRounds
+	}
+
+	bank, exists := GetBank(locale)
+	if !exists {
+		return interactions.RespondError(s, i, locale, "game.trivia.error.no_questions", true)
+	}
+
+	if categoryKey == "" {
+		keys := bank.CategoryKeys()
+		if len(keys) == 0 {
+			return interactions.RespondError(s, i, locale, "game.trivia.error.no_questions", true)
+		}
+		categoryKey = keys[time.Now().UnixNano()%int64(len(keys))]
+	}
+
+	category, exists := bank.Categories[categoryKey]
+	if !exists || len(category.Questions) == 0 {
+		return interactions.RespondError(s, i, locale, "game.trivia.error.no_questions", true)
+	}
+
+	session, ok := manager.StartSession(i.ChannelID, userID, category, rounds, locale)
+	if !ok {
+		return interactions.RespondError(s, i, locale, "game.trivia.error.already_active", true)
+	}
+
+	err := interactions.RespondCustom(s, i, &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{buildQuestionEmbed(session)},
+		Components: buildAnswerButtons(session, false),
+	})
+	if err != nil {
+		manager.EndSession(i.ChannelID)
+		return err
+	}
+
+	// Keep the message ID so the rounds can be edited in place later
+	msg, err := s.InteractionResponse(i.Interaction)
+	if err != nil {
+		manager.EndSession(i.ChannelID)
+		return fmt.Errorf("failed to fetch trivia message: %w", err)
+	}
+	session.MessageID = msg.ID
+
+	channelID := i.ChannelID
+	session.schedule(roundCountdown, func() { closeRound(s, channelID) })
+
+	return nil
+}
+
+// HandleAnswer locks in a player's answer for the current round
+func HandleAnswer(s *discordgo.Session, i *discordgo.InteractionCreate, round, choice int) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+
+	userID := interactions.UserID(i)
+	if userID == "" {
+		return fmt.Errorf("could not get user ID")
+	}
+
+	session, exists := GetManager().GetSession(i.ChannelID)
+	if !exists {
+		return interactions.RespondError(s, i, locale, "game.trivia.error.no_active_game", true)
+	}
+
+	if choice < 0 || choice >= len(choiceEmojis) {
+		return interactions.RespondError(s, i, locale, "command.unknown", true)
+	}
+
+	switch session.LockAnswer(userID, round, choice, time.Now()) {
+	case AnswerAlreadyLocked:
+		return interactions.RespondError(s, i, locale, "game.trivia.error.already_answered", true)
+	case AnswerRoundClosed:
+		return interactions.RespondError(s, i, locale, "game.trivia.error.round_closed", true)
+	}
+
+	return interactions.RespondSuccess(s, i, locale, "game.trivia.answer_locked", true, choiceEmojis[choice])
+}
+
+// closeRound reveals the answer of the current round and schedules the next one
+func closeRound(s *discordgo.Session, channelID string) {
+	session, exists := GetManager().GetSession(channelID)
+	if !exists {
+		return
+	}
+
+	correct := session.CloseRound()
+	editSessionMessage(s, session, buildRevealEmbed(session, correct), buildAnswerButtons(session, true))
+
+	session.schedule(roundPause, func() { advanceRound(s, channelID) })
+}
+
+// advanceRound shows the next question, or the final results when all rounds are done
+func advanceRound(s *discordgo.Session, channelID string) {
+	manager := GetManager()
+	session, exists := manager.GetSession(channelID)
+	if !exists {
+		return
+	}
+
+	if !session.NextRound() {
+		manager.EndSession(channelID)
+		editSessionMessage(s, session, buildResultsEmbed(session), []discordgo.MessageComponent{})
+		return
+	}
+
+	editSessionMessage(s, session, buildQuestionEmbed(session), buildAnswerButtons(session, false))
+	session.schedule(roundCountdown, func() { closeRound(s, channelID) })
+}
+
+// editSessionMessage replaces the embed and components of the trivia message
+func editSessionMessage(s *discordgo.Session, session *Session, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) {
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		Channel:    session.ChannelID,
+		ID:         session.MessageID,
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	})
+	if err != nil {
+		slog.Error("Failed to update trivia message", "channel_id", session.ChannelID, "message_id", session.MessageID, "error", err)
+	}
+}
+
+// buildQuestionEmbed builds the embed for an open round
+func buildQuestionEmbed(session *Session) *discordgo.MessageEmbed {
+	locale := session.Locale
+	question := session.CurrentQuestion()
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.Tf(locale, "game.trivia.question_title", session.Round+1, len(session.Questions), session.CategoryName),
+		Description: formatQuestion(question, false),
+		Color:       0x3498DB,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  i18n.T(locale, "game.trivia.time_left"),
+				Value: fmt.Sprintf("<t:%d:R>", session.Deadline.Unix()),
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(locale, "game.trivia.footer"),
+		},
+	}
+}
+
+// buildRevealEmbed builds the embed shown after a round closes
+func buildRevealEmbed(session *Session, correct []string) *discordgo.MessageEmbed {
+	locale := session.Locale
+	question := session.CurrentQuestion()
+
+	winners := i18n.T(locale, "game.trivia.nobody")
+	if len(correct) > 0 {
+		mentions := make([]string, len(correct))
+		for idx, userID := range correct {
+			mentions[idx] = fmt.Sprintf("<@%s>", userID)
+		}
+		winners = strings.Join(mentions, " ")
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.Tf(locale, "game.trivia.question_title", session.Round+1, len(session.Questions), session.CategoryName),
+		Description: formatQuestion(question, true),
+		Color:       0x2ECC71,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  i18n.Tf(locale, "game.trivia.correct_players", len(correct), session.AnswerCount()),
+				Value: winners,
+			},
+		},
+	}
+}
+
+// buildResultsEmbed builds the final scoreboard
+func buildResultsEmbed(session *Session) *discordgo.MessageEmbed {
+	locale := session.Locale
+	standings := session.Standings()
+
+	var builder strings.Builder
+	if len(standings) == 0 {
+		builder.WriteString(i18n.T(locale, "game.trivia.no_players"))
+	}
+	for idx, score := range standings {
+		rank := fmt.Sprintf("`#%d`", idx+1)
+		if idx < len(rankEmojis) {
+			rank = rankEmojis[idx]
+		}
+		builder.WriteString(i18n.Tf(locale, "game.trivia.result_line", rank, score.UserID, score.Points, score.Correct, len(session.Questions)))
+		builder.WriteString("\n")
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.Tf(locale, "game.trivia.results_title", session.CategoryName),
+		Description: builder.String(),
+		Color:       0xF1C40F,
+	}
+}
+
+// formatQuestion renders the question text and its choices
+func formatQuestion(question Question, reveal bool) string {
+	var builder strings.Builder
+	builder.WriteString("**" + question.Question + "**\n\n")
+	for idx, choice := range question.Choices {
+		builder.WriteString(choiceEmojis[idx] + " " + choice)
+		if reveal && idx == question.Answer {
+			builder.WriteString(" ✅")
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// buildAnswerButtons creates one button per choice; closed rounds highlight the correct one
+func buildAnswerButtons(session *Session, closed bool) []discordgo.MessageComponent {
+	question := session.CurrentQuestion()
+
+	buttons := make([]discordgo.MessageComponent, 0, len(question.Choices))
+	for idx := range question.Choices {
+		style := discordgo.PrimaryButton
+		if closed {
+			style = discordgo.SecondaryButton
+			if idx == question.Answer {
+				style = discordgo.SuccessButton
+			}
+		}
+
+		buttons = append(buttons, discordgo.Button{
+			Style:    style,
+			CustomID: fmt.Sprintf("game_trivia_answer_%d_%d", session.Round, idx),
+			Disabled: closed,
+			Emoji: &discordgo.ComponentEmoji{
+				Name: choiceEmojis[idx],
+			},
+		})
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: buttons},
+	}
+}
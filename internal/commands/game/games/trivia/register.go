@@ -0,0 +1,99 @@
+package trivia
+
+import (
+	"hiei-discord-bot/internal/commands/game"
+	"hiei-discord-bot/internal/interactions"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func init() {
+	router := interactions.GetRouter()
+
+	// Register component handler (answer buttons)
+	router.RegisterComponent("game_trivia_", handleComponentInteraction)
+
+	// Register as game subcommand
+	game.RegisterSubCommand(&SubCommand{})
+}
+
+// SubCommand implements game.SubCommand interface
+type SubCommand struct{}
+
+func (s *SubCommand) Name() string {
+	return "trivia"
+}
+
+func (s *SubCommand) Description() string {
+	return "Start a trivia quiz everyone in the channel can join"
+}
+
+func (s *SubCommand) Options() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "category",
+			Description: "Question category (default: random)",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{
+					Name:  "General Knowledge",
+					Value: "general",
+				},
+				{
+					Name:  "Science",
+					Value: "science",
+				},
+				{
+					Name:  "Geography",
+					Value: "geography",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "rounds",
+			Description: "Number of questions (1-10, default: 5)",
+			Required:    false,
+			MinValue:    float64Ptr(1),
+			MaxValue:    maxRounds,
+		},
+	}
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func (s *SubCommand) Handle(session *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return HandleStart(session, i)
+}
+
+// handleComponentInteraction routes button interactions to the appropriate handler
+func handleComponentInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	customID := i.MessageComponentData().CustomID
+
+	// Extract action from customID: game_trivia_answer_{round}_{choice}
+	parts := strings.Split(customID, "_")
+	if len(parts) != 5 || parts[2] != "answer" {
+		return nil
+	}
+
+	round, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return nil
+	}
+	choice, err := strconv.Atoi(parts[4])
+	if err != nil {
+		return nil
+	}
+
+	return HandleAnswer(s, i, round, choice)
+}
+
+// ActiveSessions returns the number of quizzes in progress
+func (s *SubCommand) ActiveSessions() int {
+	return GetManager().ActiveSessions()
+}
@@ -0,0 +1,280 @@
+package trivia
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"hiei-discord-bot/internal/i18n"
+)
+
+const (
+	defaultRounds  = 5
+	maxRounds      = 10
+	roundCountdown = 20 * time.Second
+	roundPause     = 5 * time.Second
+	basePoints     = 100
+	maxSpeedBonus  = 50
+)
+
+// AnswerResult describes the outcome of locking in an answer
+type AnswerResult int
+
+const (
+	AnswerAccepted AnswerResult = iota
+	AnswerAlreadyLocked
+	AnswerRoundClosed
+)
+
+// Answer is a player's locked answer for the current round
+type Answer struct {
+	Choice int
+	At     time.Time
+}
+
+// PlayerScore tracks a player's score across rounds
+type PlayerScore struct {
+	UserID  string
+	Points  int
+	Correct int
+}
+
+// Session represents a trivia game running in a channel
+type Session struct {
+	ChannelID    string
+	HostID       string
+	MessageID    string
+	Locale       i18n.SupportedLocale
+	CategoryName string
+	Questions    []Question
+	Round        int // Zero-based index of the current question
+	RoundStarted time.Time
+	Deadline     time.Time
+	RoundClosed  bool
+	Answers      map[string]Answer // userID -> answer for the current round
+	Scores       map[string]*PlayerScore
+	timer        *time.Timer
+	mu           sync.Mutex
+}
+
+// Manager manages active trivia sessions
+type Manager struct {
+	sessions map[string]*Session // channelID -> Session
+	mu       sync.RWMutex
+}
+
+var instance *Manager
+var once sync.Once
+
+// GetManager returns the singleton session manager
+func GetManager() *Manager {
+	once.Do(func() {
+		instance = &Manager{
+			sessions: make(map[string]*Session),
+		}
+	})
+	return instance
+}
+
+// StartSession creates a new session in a channel with up to rounds shuffled questions.
+// It returns false if the channel already has an active session.
+func (m *Manager) StartSession(channelID, hostID string, category *Category, rounds int, locale i18n.SupportedLocale) (*Session, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sessions[channelID]; exists {
+		return nil, false
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	questions := make([]Question, len(category.Questions))
+	copy(questions, category.Questions)
+	rng.Shuffle(len(questions), func(i, j int) {
+		questions[i], questions[j] = questions[j], questions[i]
+	})
+	if rounds > len(questions) {
+		rounds = len(questions)
+	}
+	questions = questions[:rounds]
+	for idx := range questions {
+		questions[idx] = shuffleChoices(rng, questions[idx])
+	}
+
+	session := &Session{
+		ChannelID:    channelID,
+		HostID:       hostID,
+		Locale:       locale,
+		CategoryName: category.Name,
+		Questions:    questions,
+		Answers:      make(map[string]Answer),
+		Scores:       make(map[string]*PlayerScore),
+	}
+	session.startRound(0)
+
+	m.sessions[channelID] = session
+	return session, true
+}
+
+// GetSession retrieves the active session of a channel
+func (m *Manager) GetSession(channelID string) (*Session, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	session, exists := m.sessions[channelID]
+	return session, exists
+}
+
+// EndSession removes a session and stops its timer
+func (m *Manager) EndSession(channelID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if session, exists := m.sessions[channelID]; exists {
+		session.mu.Lock()
+		if session.timer != nil {
+			session.timer.Stop()
+		}
+		session.mu.Unlock()
+		delete(m.sessions, channelID)
+	}
+}
+
+// ActiveSessions returns the number of quizzes in progress
+func (m *Manager) ActiveSessions() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.sessions)
+}
+
+// CurrentQuestion returns the question of the current round
+func (s *Session) CurrentQuestion() Question {
+	return s.Questions[s.Round]
+}
+
+// LockAnswer records a player's answer for the given round
+func (s *Session) LockAnswer(userID string, round, choice int, now time.Time) AnswerResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.RoundClosed || round != s.Round || now.After(s.Deadline) {
+		return AnswerRoundClosed
+	}
+	if _, exists := s.Answers[userID]; exists {
+		return AnswerAlreadyLocked
+	}
+
+	s.Answers[userID] = Answer{Choice: choice, At: now}
+	return AnswerAccepted
+}
+
+// CloseRound scores the current round and returns the IDs of players who answered correctly,
+// fastest first. Faster correct answers earn a larger bonus.
+func (s *Session) CloseRound() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.RoundClosed = true
+	question := s.CurrentQuestion()
+	total := s.Deadline.Sub(s.RoundStarted)
+
+	var correct []string
+	for userID, answer := range s.Answers {
+		score, exists := s.Scores[userID]
+		if !exists {
+			score = &PlayerScore{UserID: userID}
+			s.Scores[userID] = score
+		}
+		if answer.Choice != question.Answer {
+			continue
+		}
+
+		remaining := s.Deadline.Sub(answer.At)
+		if remaining < 0 {
+			remaining = 0
+		}
+		score.Points += basePoints + int(int64(maxSpeedBonus)*int64(remaining)/int64(total))
+		score.Correct++
+		correct = append(correct, userID)
+	}
+
+	sort.Slice(correct, func(i, j int) bool {
+		return s.Answers[correct[i]].At.Before(s.Answers[correct[j]].At)
+	})
+	return correct
+}
+
+// NextRound advances to the next question, returning false when the game is over
+func (s *Session) NextRound() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Round+1 >= len(s.Questions) {
+		return false
+	}
+	s.startRound(s.Round + 1)
+	return true
+}
+
+// Standings returns the player scores sorted by points
+func (s *Session) Standings() []PlayerScore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	standings := make([]PlayerScore, 0, len(s.Scores))
+	for _, score := range s.Scores {
+		standings = append(standings, *score)
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		return standings[i].Correct > standings[j].Correct
+	})
+	return standings
+}
+
+// AnswerCount returns how many players locked an answer this round
+func (s *Session) AnswerCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.Answers)
+}
+
+// schedule runs fn after d, replacing any pending timer
+func (s *Session) schedule(d time.Duration, fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(d, fn)
+}
+
+// startRound resets per-round state; the caller must hold the lock or own the session exclusively
+func (s *Session) startRound(round int) {
+	now := time.Now()
+	s.Round = round
+	s.RoundStarted = now
+	s.Deadline = now.Add(roundCountdown)
+	s.RoundClosed = false
+	s.Answers = make(map[string]Answer)
+}
+
+// shuffleChoices shuffles the choices of a question while keeping track of the answer
+func shuffleChoices(rng *rand.Rand, q Question) Question {
+	order := rng.Perm(len(q.Choices))
+	shuffled := Question{
+		Question: q.Question,
+		Choices:  make([]string, len(q.Choices)),
+	}
+	for newIdx, oldIdx := range order {
+		shuffled.Choices[newIdx] = q.Choices[oldIdx]
+		if oldIdx == q.Answer {
+			shuffled.Answer = newIdx
+		}
+	}
+	return shuffled
+}
@@ -24,7 +24,7 @@ func HandleStart(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	store := GetStore()
 
 	// Extract user ID
-	userID := getUserID(i)
+	userID := interactions.UserID(i)
 	if userID == "" {
 		return fmt.Errorf("could not get user ID")
 	}
@@ -108,7 +108,7 @@ func HandleButtonClick(s *discordgo.Session, i *discordgo.InteractionCreate, act
 	locale := i18n.GetUserLocaleFromInteraction(i)
 	store := GetStore()
 
-	userID := getUserID(i)
+	userID := interactions.UserID(i)
 	if userID == "" {
 		return fmt.Errorf("could not get user ID")
 	}
@@ -169,7 +169,7 @@ func HandleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) err
 	locale := i18n.GetUserLocaleFromInteraction(i)
 	store := GetStore()
 
-	userID := getUserID(i)
+	userID := interactions.UserID(i)
 	if userID == "" {
 		return fmt.Errorf("could not get user ID")
 	}
@@ -326,25 +326,6 @@ func fetchRandomWord(length int) (string, error) {
 	return words[0], nil
 }
 
-// getUserID extracts user ID from interaction
-func getUserID(i *discordgo.InteractionCreate) string {
-	if i == nil {
-		return ""
-	}
-
-	// Guild interaction (Member takes priority)
-	if i.Member != nil && i.Member.User != nil {
-		return i.Member.User.ID
-	}
-
-	// DM interaction
-	if i.User != nil {
-		return i.User.ID
-	}
-
-	return ""
-}
-
 // strPtr returns a pointer to a string
 func strPtr(s string) *string {
 	return &s
@@ -66,3 +66,8 @@ func handleComponentInteraction(s *discordgo.Session, i *discordgo.InteractionCr
 	action := parts[2] // "guess" or "giveup"
 	return HandleButtonClick(s, i, action)
 }
+
+// ActiveSessions returns the number of games in progress
+func (s *SubCommand) ActiveSessions() int {
+	return GetStore().ActiveGames()
+}
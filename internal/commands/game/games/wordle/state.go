@@ -98,6 +98,20 @@ func (s *GameStore) EndGame(userID string) {
 	}
 }
 
+// ActiveGames returns the number of games that are not completed
+func (s *GameStore) ActiveGames() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for _, game := range s.games {
+		if !game.IsCompleted {
+			count++
+		}
+	}
+	return count
+}
+
 // DeleteGame removes a game from the store
 func (s *GameStore) DeleteGame(userID string) {
 	s.mu.Lock()
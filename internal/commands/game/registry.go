@@ -1,6 +1,7 @@
 package game
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/bwmarrin/discordgo"
@@ -14,6 +15,11 @@ type SubCommand interface {
 	Handle(s *discordgo.Session, i *discordgo.InteractionCreate) error
 }
 
+// SessionCounter is implemented by sub-commands that keep games in memory
+type SessionCounter interface {
+	ActiveSessions() int
+}
+
 var (
 	subCommands = make(map[string]SubCommand)
 	mu          sync.RWMutex
@@ -26,7 +32,7 @@ func RegisterSubCommand(cmd SubCommand) {
 	subCommands[cmd.Name()] = cmd
 }
 
-// GetSubCommands returns all registered sub-commands
+// GetSubCommands returns all registered sub-commands sorted by name
 func GetSubCommands() []SubCommand {
 	mu.RLock()
 	defer mu.RUnlock()
@@ -34,6 +40,9 @@ func GetSubCommands() []SubCommand {
 	for _, cmd := range subCommands {
 		cmds = append(cmds, cmd)
 	}
+	sort.Slice(cmds, func(a, b int) bool {
+		return cmds[a].Name() < cmds[b].Name()
+	})
 	return cmds
 }
 
@@ -44,3 +53,14 @@ func GetSubCommand(name string) (SubCommand, bool) {
 	cmd, exists := subCommands[name]
 	return cmd, exists
 }
+
+// ActiveSessions returns the number of games in progress per sub-command
+func ActiveSessions() map[string]int {
+	sessions := make(map[string]int)
+	for _, cmd := range GetSubCommands() {
+		if counter, ok := cmd.(SessionCounter); ok {
+			sessions[cmd.Name()] = counter.ActiveSessions()
+		}
+	}
+	return sessions
+}
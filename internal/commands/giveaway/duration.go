@@ -0,0 +1,56 @@
+package giveaway
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	minDuration = time.Minute
+	maxDuration = 90 * 24 * time.Hour
+)
+
+var (
+	durationPattern = regexp.MustCompile(`^(\d+[smhdw])+$`)
+	durationPart    = regexp.MustCompile(`(\d+)([smhdw])`)
+
+	errInvalidDuration = errors.New("invalid duration")
+)
+
+var durationUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// parseDuration parses durations such as "30m", "2h" or "1d12h".
+// Unlike time.ParseDuration it accepts days and weeks.
+func parseDuration(text string) (time.Duration, error) {
+	text = strings.ToLower(strings.ReplaceAll(text, " ", ""))
+	if !durationPattern.MatchString(text) {
+		return 0, errInvalidDuration
+	}
+
+	var total time.Duration
+	for _, match := range durationPart.FindAllStringSubmatch(text, -1) {
+		value, err := strconv.ParseInt(match[1], 10, 64)
+		unit := durationUnits[match[2]]
+		if err != nil || value > int64(maxDuration/unit) {
+			return 0, errInvalidDuration
+		}
+		total += time.Duration(value) * unit
+		if total > maxDuration {
+			return 0, errInvalidDuration
+		}
+	}
+
+	if total < minDuration {
+		return 0, errInvalidDuration
+	}
+	return total, nil
+}
@@ -0,0 +1,162 @@
+package giveaway
+
+import (
+	"hiei-discord-bot/internal/commands"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	maxWinners     = 20
+	maxPrizeLength = 200
+)
+
+func init() {
+	commands.Register(New())
+}
+
+// Command implements the giveaway command
+type Command struct{}
+
+// New creates a new giveaway command instance
+func New() *Command {
+	return &Command{}
+}
+
+// Definition returns the slash command definition
+func (c *Command) Definition() *discordgo.ApplicationCommand {
+	manageGuild := int64(discordgo.PermissionManageGuild)
+	dmPermission := false
+
+	return &discordgo.ApplicationCommand{
+		Name:                     "giveaway",
+		Description:              "Run giveaways with scheduled draws (admin only)",
+		DefaultMemberPermissions: &manageGuild,
+		DMPermission:             &dmPermission,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "start",
+				Description: "Start a giveaway",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "prize",
+						Description: "What the winners get",
+						Required:    true,
+						MaxLength:   maxPrizeLength,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "duration",
+						Description: "How long the giveaway runs, e.g. 30m, 2h, 1d12h (max 90d)",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "winners",
+						Description: "Number of winners (default: 1)",
+						Required:    false,
+						MinValue:    float64Ptr(1),
+						MaxValue:    maxWinners,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        "required_role",
+						Description: "Only members with this role can enter",
+						Required:    false,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionChannel,
+						Name:         "channel",
+						Description:  "Channel to post the giveaway in (default: this channel)",
+						Required:     false,
+						ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "end",
+				Description: "End a giveaway now and draw the winners",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "id",
+						Description: "Giveaway ID (see /giveaway list)",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "reroll",
+				Description: "Draw new winners for an ended giveaway",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "id",
+						Description: "Giveaway ID (shown in the giveaway footer)",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "winners",
+						Description: "Number of new winners (default: same as the giveaway)",
+						Required:    false,
+						MinValue:    float64Ptr(1),
+						MaxValue:    maxWinners,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "list",
+				Description: "List the running giveaways in this server",
+			},
+		},
+	}
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+// Version returns the command version
+func (c *Command) Version() string {
+	return "1.0.0"
+}
+
+// Describe returns the help metadata
+func (c *Command) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryUtility}
+}
+
+// Execute runs the giveaway command
+func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		return nil
+	}
+
+	if i.GuildID == "" {
+		return interactions.RespondError(s, i, locale, "giveaway.error.guild_only", true)
+	}
+
+	subcommand := data.Options[0]
+	switch subcommand.Name {
+	case "start":
+		return handleStart(s, i, locale, subcommand.Options)
+	case "end":
+		return handleEnd(s, i, locale, subcommand.Options)
+	case "reroll":
+		return handleReroll(s, i, locale, subcommand.Options)
+	case "list":
+		return handleList(s, i, locale)
+	}
+
+	return interactions.RespondError(s, i, locale, "command.unknown", true)
+}
@@ -0,0 +1,359 @@
+package giveaway
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"hiei-discord-bot/internal/giveaways"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+	"hiei-discord-bot/internal/models"
+	"hiei-discord-bot/internal/scheduler"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	enterButtonPrefix  = "giveaway_enter_"
+	maxListedGiveaways = 20
+)
+
+// handleStart posts the giveaway message and schedules its draw
+func handleStart(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	giveaway := &models.Giveaway{
+		ID:          giveaways.NewID(),
+		GuildID:     i.GuildID,
+		ChannelID:   i.ChannelID,
+		HostID:      interactions.UserID(i),
+		WinnerCount: 1,
+		Locale:      string(locale),
+	}
+
+	var durationText string
+	for _, opt := range options {
+		switch opt.Name {
+		case "prize":
+			giveaway.Prize = strings.TrimSpace(opt.StringValue())
+		case "duration":
+			durationText = opt.StringValue()
+		case "winners":
+			giveaway.WinnerCount = int(opt.IntValue())
+		case "required_role":
+			giveaway.RequiredRoleID = opt.RoleValue(nil, "").ID
+		case "channel":
+			giveaway.ChannelID = opt.ChannelValue(nil).ID
+		}
+	}
+
+	duration, err := parseDuration(durationText)
+	if err != nil {
+		return interactions.RespondError(s, i, locale, "giveaway.error.invalid_duration", true)
+	}
+	if giveaway.Prize == "" {
+		return interactions.RespondError(s, i, locale, "giveaway.error.no_prize", true)
+	}
+	giveaway.EndsAt = time.Now().Add(duration).Truncate(time.Second)
+
+	message, err := s.ChannelMessageSendComplex(giveaway.ChannelID, &discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{buildGiveawayEmbed(giveaway, 0)},
+		Components: buildGiveawayComponents(giveaway),
+	})
+	if err != nil {
+		return interactions.RespondError(s, i, locale, "giveaway.error.post_failed", true)
+	}
+	giveaway.MessageID = message.ID
+
+	if err := giveaways.GetManager().Create(giveaway); err != nil {
+		// Do not leave a giveaway nobody can win
+		s.ChannelMessageDelete(message.ChannelID, message.ID)
+		return err
+	}
+	scheduleDraw(giveaway.ID, giveaway.EndsAt)
+
+	return interactions.RespondSuccess(s, i, locale, "giveaway.started", true, giveaway.Prize, giveaway.ChannelID, giveaway.ID)
+}
+
+// handleEnd draws a running giveaway before its scheduled end
+func handleEnd(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	id := getIDOption(options)
+	if _, err := getGuildGiveaway(id, i.GuildID); err != nil {
+		return respondManagerError(s, i, locale, err)
+	}
+
+	scheduler.Get().Cancel(drawJobID(id))
+	if err := drawGiveaway(s, id); err != nil {
+		return respondManagerError(s, i, locale, err)
+	}
+
+	return interactions.RespondSuccess(s, i, locale, "giveaway.ended_manually", true, id)
+}
+
+// handleReroll draws new winners for an ended giveaway
+func handleReroll(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	id := getIDOption(options)
+	count := 0
+	for _, opt := range options {
+		if opt.Name == "winners" {
+			count = int(opt.IntValue())
+		}
+	}
+
+	if _, err := getGuildGiveaway(id, i.GuildID); err != nil {
+		return respondManagerError(s, i, locale, err)
+	}
+
+	giveaway, err := giveaways.GetManager().Reroll(id, count)
+	if err != nil {
+		return respondManagerError(s, i, locale, err)
+	}
+
+	if err := announceWinners(s, giveaway, true); err != nil {
+		slog.Error("Failed to announce giveaway reroll", "id", id, "error", err)
+	}
+	return interactions.RespondSuccess(s, i, locale, "giveaway.rerolled", true, id)
+}
+
+// handleList shows the running giveaways of the guild
+func handleList(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale) error {
+	mgr := giveaways.GetManager()
+	active, err := mgr.Active(i.GuildID)
+	if err != nil {
+		return err
+	}
+	if len(active) == 0 {
+		return interactions.RespondError(s, i, locale, "giveaway.list.empty", true)
+	}
+
+	var builder strings.Builder
+	for idx, giveaway := range active {
+		if idx == maxListedGiveaways {
+			builder.WriteString(i18n.Tf(locale, "giveaway.list.more", len(active)-maxListedGiveaways))
+			break
+		}
+		entries, _ := mgr.EntryCount(giveaway.ID)
+		builder.WriteString(i18n.Tf(locale, "giveaway.list.item",
+			giveaway.ID, giveaway.Prize, messageLink(&giveaway), giveaway.EndsAt.Unix(), entries))
+		builder.WriteString("\n")
+	}
+
+	return interactions.RespondCustom(s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       i18n.T(locale, "giveaway.list.title"),
+				Description: builder.String(),
+				Color:       0xF1C40F,
+			},
+		},
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// HandleEnter handles the Enter button of a giveaway
+func HandleEnter(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	id := strings.TrimPrefix(i.MessageComponentData().CustomID, enterButtonPrefix)
+	mgr := giveaways.GetManager()
+
+	giveaway, err := mgr.Get(id)
+	if err != nil {
+		return respondManagerError(s, i, locale, err)
+	}
+
+	if giveaway.RequiredRoleID != "" && (i.Member == nil || !hasRole(i.Member, giveaway.RequiredRoleID)) {
+		return interactions.RespondError(s, i, locale, "giveaway.error.missing_role", true, giveaway.RequiredRoleID)
+	}
+
+	entries, err := mgr.Enter(id, interactions.UserID(i))
+	if err != nil {
+		return respondManagerError(s, i, locale, err)
+	}
+
+	return interactions.RespondSuccess(s, i, locale, "giveaway.entered", true, giveaway.Prize, entries)
+}
+
+// scheduleDraw schedules the automatic draw at the giveaway's end time
+func scheduleDraw(id string, at time.Time) {
+	scheduler.Get().Schedule(drawJobID(id), at, func(s *discordgo.Session) {
+		if err := drawGiveaway(s, id); err != nil && !errors.Is(err, giveaways.ErrEnded) {
+			slog.Error("Failed to draw giveaway", "id", id, "error", err)
+		}
+	})
+}
+
+func drawJobID(id string) string {
+	return "giveaway:" + id
+}
+
+// drawGiveaway ends a giveaway, updates its message and announces the winners
+func drawGiveaway(s *discordgo.Session, id string) error {
+	giveaway, err := giveaways.GetManager().End(id)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Giveaway drawn", "id", id, "guild_id", giveaway.GuildID, "winners", len(giveaway.WinnerIDs))
+	return announceWinners(s, giveaway, false)
+}
+
+// announceWinners edits the giveaway message to its ended state and replies with the winners
+func announceWinners(s *discordgo.Session, giveaway *models.Giveaway, reroll bool) error {
+	locale := i18n.SupportedLocale(giveaway.Locale)
+	entries, _ := giveaways.GetManager().EntryCount(giveaway.ID)
+
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         giveaway.MessageID,
+		Channel:    giveaway.ChannelID,
+		Embeds:     &[]*discordgo.MessageEmbed{buildGiveawayEmbed(giveaway, entries)},
+		Components: &[]discordgo.MessageComponent{},
+	})
+	if err != nil {
+		// The message may have been deleted; still announce the result
+		slog.Warn("Failed to update giveaway message", "id", giveaway.ID, "error", err)
+	}
+
+	var content string
+	switch {
+	case len(giveaway.WinnerIDs) == 0:
+		content = i18n.Tf(locale, "giveaway.announce.no_entries", giveaway.Prize)
+	case reroll:
+		content = i18n.Tf(locale, "giveaway.announce.rerolled", mentionList(giveaway.WinnerIDs), giveaway.Prize)
+	default:
+		content = i18n.Tf(locale, "giveaway.announce.winners", mentionList(giveaway.WinnerIDs), giveaway.Prize)
+	}
+
+	_, err = s.ChannelMessageSendComplex(giveaway.ChannelID, &discordgo.MessageSend{
+		Content: content,
+		Reference: &discordgo.MessageReference{
+			MessageID:       giveaway.MessageID,
+			ChannelID:       giveaway.ChannelID,
+			FailIfNotExists: boolPtr(false),
+		},
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Users: giveaway.WinnerIDs,
+		},
+	})
+	return err
+}
+
+// buildGiveawayEmbed renders a running or ended giveaway
+func buildGiveawayEmbed(giveaway *models.Giveaway, entries int) *discordgo.MessageEmbed {
+	locale := i18n.SupportedLocale(giveaway.Locale)
+	var builder strings.Builder
+
+	if giveaway.Ended {
+		builder.WriteString(i18n.Tf(locale, "giveaway.embed.ended_at", giveaway.EndsAt.Unix()) + "\n")
+		if len(giveaway.WinnerIDs) == 0 {
+			builder.WriteString(i18n.T(locale, "giveaway.embed.no_winners") + "\n")
+		} else {
+			builder.WriteString(i18n.Tf(locale, "giveaway.embed.winners", mentionList(giveaway.WinnerIDs)) + "\n")
+		}
+		builder.WriteString(i18n.Tf(locale, "giveaway.embed.entries", entries) + "\n")
+	} else {
+		builder.WriteString(i18n.T(locale, "giveaway.embed.how_to_enter") + "\n\n")
+		builder.WriteString(i18n.Tf(locale, "giveaway.embed.ends_at", giveaway.EndsAt.Unix(), giveaway.EndsAt.Unix()) + "\n")
+		builder.WriteString(i18n.Tf(locale, "giveaway.embed.winner_count", giveaway.WinnerCount) + "\n")
+		if giveaway.RequiredRoleID != "" {
+			builder.WriteString(i18n.Tf(locale, "giveaway.embed.required_role", giveaway.RequiredRoleID) + "\n")
+		}
+	}
+	builder.WriteString(i18n.Tf(locale, "giveaway.embed.hosted_by", giveaway.HostID))
+
+	color := 0xF1C40F
+	if giveaway.Ended {
+		color = 0x95A5A6
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "🎉 " + giveaway.Prize,
+		Description: builder.String(),
+		Color:       color,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.Tf(locale, "giveaway.embed.footer", giveaway.ID),
+		},
+		Timestamp: giveaway.EndsAt.Format(time.RFC3339),
+	}
+}
+
+// buildGiveawayComponents creates the Enter button
+func buildGiveawayComponents(giveaway *models.Giveaway) []discordgo.MessageComponent {
+	locale := i18n.SupportedLocale(giveaway.Locale)
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    i18n.T(locale, "giveaway.button.enter"),
+					Style:    discordgo.SuccessButton,
+					CustomID: enterButtonPrefix + giveaway.ID,
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "🎉",
+					},
+				},
+			},
+		},
+	}
+}
+
+// respondManagerError maps giveaway manager errors to localized messages
+func respondManagerError(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, err error) error {
+	switch {
+	case errors.Is(err, giveaways.ErrNotFound):
+		return interactions.RespondError(s, i, locale, "giveaway.error.not_found", true)
+	case errors.Is(err, giveaways.ErrEnded):
+		return interactions.RespondError(s, i, locale, "giveaway.error.ended", true)
+	case errors.Is(err, giveaways.ErrNotEnded):
+		return interactions.RespondError(s, i, locale, "giveaway.error.not_ended", true)
+	case errors.Is(err, giveaways.ErrAlreadyEntered):
+		return interactions.RespondError(s, i, locale, "giveaway.error.already_entered", true)
+	}
+	return err
+}
+
+// getGuildGiveaway loads a giveaway, treating giveaways of other guilds as not found
+func getGuildGiveaway(id, guildID string) (*models.Giveaway, error) {
+	giveaway, err := giveaways.GetManager().Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if giveaway.GuildID != guildID {
+		return nil, giveaways.ErrNotFound
+	}
+	return giveaway, nil
+}
+
+func getIDOption(options []*discordgo.ApplicationCommandInteractionDataOption) string {
+	for _, opt := range options {
+		if opt.Name == "id" {
+			return strings.ToLower(strings.TrimSpace(opt.StringValue()))
+		}
+	}
+	return ""
+}
+
+func messageLink(giveaway *models.Giveaway) string {
+	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s", giveaway.GuildID, giveaway.ChannelID, giveaway.MessageID)
+}
+
+func mentionList(userIDs []string) string {
+	mentions := make([]string, len(userIDs))
+	for idx, userID := range userIDs {
+		mentions[idx] = fmt.Sprintf("<@%s>", userID)
+	}
+	return strings.Join(mentions, ", ")
+}
+
+func hasRole(member *discordgo.Member, roleID string) bool {
+	for _, id := range member.Roles {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,35 @@
+package giveaway
+
+import (
+	"hiei-discord-bot/internal/giveaways"
+	"hiei-discord-bot/internal/interactions"
+	"hiei-discord-bot/internal/scheduler"
+	"log/slog"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func init() {
+	router := interactions.GetRouter()
+
+	// Register Enter button handler
+	router.RegisterComponent(enterButtonPrefix, HandleEnter)
+
+	// Reschedule draws that were pending when the bot stopped
+	scheduler.Get().OnStart(restorePendingDraws)
+}
+
+// restorePendingDraws schedules the draw of every giveaway that has not ended.
+// Giveaways that ended while the bot was offline are drawn immediately.
+func restorePendingDraws(s *discordgo.Session) {
+	pending, err := giveaways.GetManager().Pending()
+	if err != nil {
+		slog.Error("Failed to load pending giveaways", "error", err)
+		return
+	}
+
+	for _, giveaway := range pending {
+		scheduleDraw(giveaway.ID, giveaway.EndsAt)
+	}
+	slog.Info("Restored pending giveaways", "count", len(pending))
+}
@@ -1,8 +1,8 @@
 package help
 
 import (
-	"fmt"
 	"hiei-discord-bot/internal/commands"
+	"hiei-discord-bot/internal/i18n"
 	"hiei-discord-bot/internal/interactions"
 	"strings"
 
@@ -13,12 +13,6 @@ func init() {
 	commands.Register(New())
 }
 
-// CommandInfo represents basic command information
-type CommandInfo struct {
-	Name        string
-	Description string
-}
-
 // Command implements the help slash command
 type Command struct{}
 
@@ -32,27 +26,55 @@ func (c *Command) Definition() *discordgo.ApplicationCommand {
 	return &discordgo.ApplicationCommand{
 		Name:        "help",
 		Description: "Display all available commands",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "command",
+				Description: "Show the details of a command, e.g. blame or random",
+				Required:    false,
+				MaxLength:   100,
+			},
+		},
 	}
 }
 
 // Version returns the command version
 func (c *Command) Version() string {
-	return "1.0.0"
+	return "2.0.0"
+}
+
+// Describe returns the help metadata
+func (c *Command) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryGeneral}
 }
 
 // Execute runs the help command
 func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	var builder strings.Builder
-	builder.WriteString("📋 **Available Commands:**\n\n")
+	locale := i18n.GetUserLocaleFromInteraction(i)
 
-	cmds := commands.Global().All()
-	for _, cmd := range cmds {
-		def := cmd.Definition()
-		builder.WriteString(fmt.Sprintf("`/%s` - %s\n", def.Name, def.Description))
+	var name string
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "command" {
+			name = opt.StringValue()
+		}
 	}
 
-	return interactions.RespondCustom(s, i, &discordgo.InteractionResponseData{
-		Content: builder.String(),
-		Flags:   discordgo.MessageFlagsEphemeral,
-	})
+	pages := buildPages(i, locale)
+	if len(pages) == 0 {
+		return interactions.RespondError(s, i, locale, "command.help.error.no_commands", true)
+	}
+
+	if name == "" {
+		data := listPageData(locale, pages, 0)
+		data.Flags = discordgo.MessageFlagsEphemeral
+		return interactions.RespondCustom(s, i, data)
+	}
+
+	doc, page, ok := findDoc(pages, name)
+	if !ok {
+		return interactions.RespondError(s, i, locale, "command.help.error.not_found", true, strings.TrimPrefix(strings.TrimSpace(name), "/"))
+	}
+	data := detailPageData(locale, doc, page)
+	data.Flags = discordgo.MessageFlagsEphemeral
+	return interactions.RespondCustom(s, i, data)
 }
@@ -0,0 +1,302 @@
+package help
+
+import (
+	"fmt"
+	"hiei-discord-bot/internal/commands"
+	"hiei-discord-bot/internal/i18n"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// pageSize is the number of commands listed per page
+	pageSize = 10
+
+	pageButtonPrefix = "help_page_"
+	detailSelectID   = "help_detail"
+	embedColor       = 0x5865F2
+
+	// Discord's limits on embeds
+	maxEmbedLength = 6000
+	maxEmbedFields = 25
+)
+
+// page is one page of the command list; a page never mixes categories
+type page struct {
+	category string
+	docs     []commands.Doc
+}
+
+// buildPages groups the commands the user can run by category and splits them into pages
+func buildPages(i *discordgo.InteractionCreate, locale i18n.SupportedLocale) []page {
+	byCategory := make(map[string][]commands.Doc)
+	for _, cmd := range commands.Global().All() {
+		doc := commands.Document(cmd, locale)
+		if doc.Metadata.Hidden || !canRun(i, doc) {
+			continue
+		}
+		byCategory[doc.Metadata.Category] = append(byCategory[doc.Metadata.Category], doc)
+	}
+
+	var pages []page
+	for _, category := range commands.SortCategories(byCategory) {
+		docs := byCategory[category]
+		commands.SortDocs(docs)
+		for start := 0; start < len(docs); start += pageSize {
+			end := min(start+pageSize, len(docs))
+			pages = append(pages, page{category: category, docs: docs[start:end]})
+		}
+	}
+	return pages
+}
+
+// canRun reports whether the user may run a command by its permissions.
+// Per-server overrides set in the Discord integration settings are not visible to the bot.
+func canRun(i *discordgo.InteractionCreate, doc commands.Doc) bool {
+	if i.GuildID == "" || i.Member == nil {
+		return doc.Definition.DMPermission == nil || *doc.Definition.DMPermission
+	}
+	if i.Member.Permissions&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+
+	// A zero default permission disables the command for everyone but administrators
+	if doc.Definition.DefaultMemberPermissions != nil && *doc.Definition.DefaultMemberPermissions == 0 {
+		return false
+	}
+	required := doc.Metadata.Permissions
+	return i.Member.Permissions&required == required
+}
+
+// findDoc looks up a command by name, accepting a leading slash.
+// Slash commands take precedence over context menus of the same name.
+func findDoc(pages []page, name string) (commands.Doc, int, bool) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "/"))
+	for _, key := range []string{name, "user:" + name, "message:" + name} {
+		if doc, index, ok := findDocByKey(pages, key); ok {
+			return doc, index, true
+		}
+	}
+	return commands.Doc{}, 0, false
+}
+
+// findDocByKey returns a command and the index of the page listing it
+func findDocByKey(pages []page, key string) (commands.Doc, int, bool) {
+	for index, p := range pages {
+		for _, doc := range p.docs {
+			if doc.Key == key {
+				return doc, index, true
+			}
+		}
+	}
+	return commands.Doc{}, 0, false
+}
+
+// listPageData renders one page of the command list
+func listPageData(locale i18n.SupportedLocale, pages []page, index int) *discordgo.InteractionResponseData {
+	index = min(max(index, 0), len(pages)-1)
+	current := pages[index]
+
+	var builder strings.Builder
+	options := make([]discordgo.SelectMenuOption, 0, len(current.docs))
+	for _, doc := range current.docs {
+		builder.WriteString(fmt.Sprintf("`%s` - %s\n", displayName(locale, doc), doc.Description))
+		options = append(options, discordgo.SelectMenuOption{
+			Label:       truncate(displayName(locale, doc), 100),
+			Value:       doc.Key,
+			Description: truncate(doc.Description, 100),
+		})
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       i18n.Tf(locale, "command.help.title", categoryName(locale, current.category)),
+				Description: builder.String(),
+				Color:       embedColor,
+				Footer: &discordgo.MessageEmbedFooter{
+					Text: i18n.Tf(locale, "command.help.footer", index+1, len(pages)),
+				},
+			},
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.SelectMenu{
+						CustomID:    detailSelectID,
+						Placeholder: i18n.T(locale, "command.help.select_placeholder"),
+						Options:     options,
+					},
+				},
+			},
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(locale, "command.help.button.previous"),
+						Style:    discordgo.SecondaryButton,
+						CustomID: fmt.Sprintf("%sprev_%d", pageButtonPrefix, index-1),
+						Disabled: index == 0,
+					},
+					discordgo.Button{
+						Label:    i18n.T(locale, "command.help.button.next"),
+						Style:    discordgo.SecondaryButton,
+						CustomID: fmt.Sprintf("%snext_%d", pageButtonPrefix, index+1),
+						Disabled: index == len(pages)-1,
+					},
+				},
+			},
+		},
+	}
+}
+
+// detailPageData renders the details of a command with a button back to its list page
+func detailPageData(locale i18n.SupportedLocale, doc commands.Doc, pageIndex int) *discordgo.InteractionResponseData {
+	description := doc.Description
+	if doc.Details != "" {
+		description += "\n\n" + doc.Details
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       displayName(locale, doc),
+		Description: truncate(description, 4096),
+		Color:       embedColor,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.Tf(locale, "command.help.version", categoryName(locale, doc.Metadata.Category), doc.Version),
+		},
+	}
+
+	// Commands with many subcommands leave out option lists, then notes, to fit the embed size limit
+	for _, detail := range []usageDetail{detailFull, detailNoOptions, detailBrief} {
+		embed.Fields = detailFields(locale, doc, detail)
+		if embedLength(embed) <= maxEmbedLength {
+			break
+		}
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{embed},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(locale, "command.help.button.back"),
+						Style:    discordgo.SecondaryButton,
+						CustomID: fmt.Sprintf("%sback_%d", pageButtonPrefix, pageIndex),
+					},
+				},
+			},
+		},
+	}
+}
+
+// usageDetail controls how much of each usage a detail page shows
+type usageDetail int
+
+const (
+	detailFull usageDetail = iota
+	detailNoOptions
+	detailBrief
+)
+
+// detailFields lists the usages, examples and required permissions of a command
+func detailFields(locale i18n.SupportedLocale, doc commands.Doc, detail usageDetail) []*discordgo.MessageEmbedField {
+	var fields []*discordgo.MessageEmbedField
+	switch doc.Definition.Type {
+	case discordgo.UserApplicationCommand, discordgo.MessageApplicationCommand:
+		usageKey := "command.help.usage.user"
+		if doc.Definition.Type == discordgo.MessageApplicationCommand {
+			usageKey = "command.help.usage.message"
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(locale, "command.help.field.usage"),
+			Value: i18n.Tf(locale, usageKey, doc.Definition.Name),
+		})
+	default:
+		for _, usage := range doc.Usages {
+			fields = append(fields, usageField(usage, detail))
+		}
+	}
+
+	if len(doc.Examples) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(locale, "command.help.field.examples"),
+			Value: truncate(strings.Join(doc.Examples, "\n"), 1024),
+		})
+	}
+
+	if permissions := commands.PermissionNames(locale, doc.Metadata.Permissions); permissions != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(locale, "command.help.field.permissions"),
+			Value: permissions,
+		})
+	}
+
+	// Discord allows at most 25 fields per embed
+	if len(fields) > maxEmbedFields {
+		fields = fields[:maxEmbedFields]
+	}
+	return fields
+}
+
+// usageField shows an invocation with its description and, depending on detail, options and notes
+func usageField(usage commands.Usage, detail usageDetail) *discordgo.MessageEmbedField {
+	lines := []string{usage.Description}
+	if detail == detailFull {
+		for _, opt := range usage.Options {
+			line := fmt.Sprintf("• `%s` - %s", opt.Name, opt.Description)
+			if len(opt.Choices) > 0 {
+				line += fmt.Sprintf(" (`%s`)", strings.Join(opt.Choices, "`, `"))
+			}
+			lines = append(lines, line)
+		}
+	}
+	if detail != detailBrief {
+		for _, note := range usage.Notes {
+			lines = append(lines, "› "+note)
+		}
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  truncate(usage.Line, 256),
+		Value: truncate(strings.Join(lines, "\n"), 1024),
+	}
+}
+
+// embedLength counts the characters Discord limits across an embed
+func embedLength(embed *discordgo.MessageEmbed) int {
+	length := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+	if embed.Footer != nil {
+		length += utf8.RuneCountInString(embed.Footer.Text)
+	}
+	for _, field := range embed.Fields {
+		length += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	return length
+}
+
+// displayName is how a command is written in lists and titles
+func displayName(locale i18n.SupportedLocale, doc commands.Doc) string {
+	switch doc.Definition.Type {
+	case discordgo.UserApplicationCommand:
+		return i18n.Tf(locale, "command.help.context.user", doc.Definition.Name)
+	case discordgo.MessageApplicationCommand:
+		return i18n.Tf(locale, "command.help.context.message", doc.Definition.Name)
+	default:
+		return "/" + doc.Definition.Name
+	}
+}
+
+func categoryName(locale i18n.SupportedLocale, category string) string {
+	return i18n.TDefault(locale, "command.help.category."+category, category)
+}
+
+// truncate shortens text to at most max runes
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
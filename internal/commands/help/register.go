@@ -0,0 +1,59 @@
+package help
+
+import (
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func init() {
+	router := interactions.GetRouter()
+
+	// Register page navigation and command detail handlers
+	router.RegisterComponent(pageButtonPrefix, HandlePageButton)
+	router.RegisterComponent(detailSelectID, HandleDetailSelect)
+}
+
+// HandlePageButton shows another page of the command list.
+// CustomID: help_page_{prev|next|back}_{pageIndex}
+func HandlePageButton(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+
+	customID := i.MessageComponentData().CustomID
+	index, err := strconv.Atoi(customID[strings.LastIndex(customID, "_")+1:])
+	if err != nil {
+		return interactions.RespondError(s, i, locale, "command.help.error.not_found", true, customID)
+	}
+
+	pages := buildPages(i, locale)
+	if len(pages) == 0 {
+		return interactions.RespondError(s, i, locale, "command.help.error.no_commands", true)
+	}
+	return updateMessage(s, i, listPageData(locale, pages, index))
+}
+
+// HandleDetailSelect shows the details of the command picked from the list
+func HandleDetailSelect(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+
+	values := i.MessageComponentData().Values
+	if len(values) == 0 {
+		return nil
+	}
+
+	doc, index, ok := findDocByKey(buildPages(i, locale), values[0])
+	if !ok {
+		return interactions.RespondError(s, i, locale, "command.help.error.not_found", true, values[0])
+	}
+	return updateMessage(s, i, detailPageData(locale, doc, index))
+}
+
+func updateMessage(s *discordgo.Session, i *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: data,
+	})
+}
@@ -33,6 +33,11 @@ func (c *Command) Version() string {
 	return "1.0.0"
 }
 
+// Describe returns the help metadata
+func (c *Command) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryGeneral}
+}
+
 // Execute runs the ping command
 func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	// Get user locale
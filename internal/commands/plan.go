@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"hiei-discord-bot/internal/settings"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ErrUnknownCommand is returned when a sync is limited to a command that is
+// neither registered locally nor on Discord
+var ErrUnknownCommand = errors.New("unknown command")
+
+// ChangeKind is what a sync does to a command
+type ChangeKind string
+
+const (
+	ChangeCreate ChangeKind = "create"
+	ChangeUpdate ChangeKind = "update"
+	ChangeDelete ChangeKind = "delete"
+)
+
+// Change is a single command a sync creates, updates or deletes
+type Change struct {
+	Kind       ChangeKind
+	Key        string
+	Definition *discordgo.ApplicationCommand // Local definition, nil for deletes
+	Existing   *discordgo.ApplicationCommand // Command registered on Discord, nil for creates
+	Fields     []string                      // Fields an update changes
+}
+
+// SyncPlan is the difference between the registry and the commands registered in a guild
+type SyncPlan struct {
+	GuildID   string
+	Command   string // Registry key the plan is limited to, empty for all commands
+	Changes   []Change
+	Unchanged []string // Keys of commands that are already up to date
+
+	definitions []*discordgo.ApplicationCommand
+}
+
+// PlanGuildSync compares the registry with the commands registered in a guild.
+// When command is set, the plan only covers that registry key.
+func PlanGuildSync(session *discordgo.Session, registry *Registry, guildID, command string) (*SyncPlan, error) {
+	existing, err := session.ApplicationCommands(session.State.User.ID, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch existing commands: %w", err)
+	}
+
+	plan := &SyncPlan{
+		GuildID:     guildID,
+		Command:     command,
+		definitions: registry.GetDefinitions(),
+	}
+
+	existingMap := make(map[string]*discordgo.ApplicationCommand, len(existing))
+	for _, cmd := range existing {
+		existingMap[Key(cmd)] = cmd
+	}
+
+	found := false
+	for _, def := range plan.definitions {
+		key := Key(def)
+		remote, exists := existingMap[key]
+		delete(existingMap, key)
+		if command != "" && key != command {
+			continue
+		}
+		found = true
+
+		switch {
+		case !exists:
+			plan.Changes = append(plan.Changes, Change{Kind: ChangeCreate, Key: key, Definition: def})
+		default:
+			if fields := diffCommand(def, remote); len(fields) > 0 {
+				plan.Changes = append(plan.Changes, Change{Kind: ChangeUpdate, Key: key, Definition: def, Existing: remote, Fields: fields})
+			} else {
+				plan.Unchanged = append(plan.Unchanged, key)
+			}
+		}
+	}
+
+	// Whatever is left on Discord is no longer in the registry
+	obsolete := make([]string, 0, len(existingMap))
+	for key := range existingMap {
+		obsolete = append(obsolete, key)
+	}
+	sort.Strings(obsolete)
+	for _, key := range obsolete {
+		if command != "" && key != command {
+			continue
+		}
+		found = true
+		plan.Changes = append(plan.Changes, Change{Kind: ChangeDelete, Key: key, Existing: existingMap[key]})
+	}
+
+	if !found {
+		return nil, ErrUnknownCommand
+	}
+	return plan, nil
+}
+
+// ApplyGuildSync applies a plan. A full plan replaces all guild commands with a
+// single bulk overwrite; a plan limited to one command only touches that command.
+func ApplyGuildSync(session *discordgo.Session, plan *SyncPlan) error {
+	if len(plan.Changes) == 0 {
+		return nil
+	}
+
+	appID := session.State.User.ID
+	if plan.Command == "" {
+		if _, err := session.ApplicationCommandBulkOverwrite(appID, plan.GuildID, plan.definitions); err != nil {
+			return fmt.Errorf("failed to overwrite commands: %w", err)
+		}
+	} else {
+		change := plan.Changes[0]
+		var err error
+		switch change.Kind {
+		case ChangeCreate:
+			_, err = session.ApplicationCommandCreate(appID, plan.GuildID, change.Definition)
+		case ChangeUpdate:
+			_, err = session.ApplicationCommandEdit(appID, plan.GuildID, change.Existing.ID, change.Definition)
+		case ChangeDelete:
+			err = session.ApplicationCommandDelete(appID, plan.GuildID, change.Existing.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s command %s: %w", change.Kind, change.Key, err)
+		}
+	}
+
+	recordGuildSync(plan)
+	slog.Info("Applied command sync", "guild_id", plan.GuildID, "command", plan.Command, "changes", len(plan.Changes))
+	return nil
+}
+
+// recordGuildSync updates the guild version table after a plan was applied
+func recordGuildSync(plan *SyncPlan) {
+	mgr := settings.GetManager()
+	now := time.Now().UTC()
+
+	for _, change := range plan.Changes {
+		var err error
+		if change.Kind == ChangeDelete {
+			err = mgr.DeleteGuildCommandVersion(plan.GuildID, change.Key)
+		} else {
+			err = mgr.UpdateGuildCommandLastVersionTime(plan.GuildID, change.Key, now)
+		}
+		if err != nil {
+			slog.Warn("Failed to record guild command version", "guild_id", plan.GuildID, "name", change.Key, "error", err)
+		}
+	}
+}
@@ -90,6 +90,9 @@ func GetGroupPageData(s *discordgo.Session, i *discordgo.InteractionCreate, modu
 				displayVal = "#" + ch.Name
 			}
 		}
+		if def.Type == settings.TypeText {
+			displayVal = summarizeText(displayVal)
+		}
 
 		options = append(options, discordgo.SelectMenuOption{
 			Label: i18n.T(locale, def.LabelKey),
@@ -151,7 +154,7 @@ func handleItemSelect(s *discordgo.Session, i *discordgo.InteractionCreate) erro
 
 	breadcrumb := i18n.T(locale, "setting.title") + " > " + i18n.T(locale, fmt.Sprintf("setting.module.%s", targetDef.Module)) + " > " + i18n.T(locale, targetDef.LabelKey)
 
-	if targetDef.Type == settings.TypeSelect {
+	if targetDef.Type == settings.TypeSelect || targetDef.Type == settings.TypeBool {
 		var options []discordgo.SelectMenuOption
 		for _, opt := range targetDef.Options {
 			options = append(options, discordgo.SelectMenuOption{
@@ -159,6 +162,12 @@ func handleItemSelect(s *discordgo.Session, i *discordgo.InteractionCreate) erro
 				Value: opt,
 			})
 		}
+		if targetDef.Type == settings.TypeBool {
+			options = []discordgo.SelectMenuOption{
+				{Label: i18n.T(locale, "setting.bool.true"), Value: "true"},
+				{Label: i18n.T(locale, "setting.bool.false"), Value: "false"},
+			}
+		}
 
 		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseUpdateMessage,
@@ -216,7 +225,30 @@ func handleItemSelect(s *discordgo.Session, i *discordgo.InteractionCreate) erro
 		})
 	}
 
-	// For String/Int, show Modal
+	input := discordgo.TextInput{
+		CustomID:    "value",
+		Label:       i18n.T(locale, "setting.input_value_label"),
+		Style:       discordgo.TextInputShort,
+		Placeholder: fmt.Sprintf("%v", targetDef.Default),
+		Required:    true,
+	}
+
+	// Text settings are edited in place and may be cleared to restore the default
+	if targetDef.Type == settings.TypeText {
+		targetID := interactions.UserID(i)
+		if targetDef.Scope == settings.ScopeGuild {
+			targetID = i.GuildID
+		}
+		val, _ := mgr.GetSettingValue(targetDef.Scope, targetID, key)
+
+		input.Style = discordgo.TextInputParagraph
+		input.Placeholder = ""
+		input.Value = fmt.Sprintf("%v", val)
+		input.Required = false
+		input.MaxLength = 4000
+	}
+
+	// For String/Int/Text, show Modal
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
@@ -224,15 +256,7 @@ func handleItemSelect(s *discordgo.Session, i *discordgo.InteractionCreate) erro
 			Title:    i18n.T(locale, targetDef.LabelKey),
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{
-						discordgo.TextInput{
-							CustomID:    "value",
-							Label:       i18n.T(locale, "setting.input_value_label"),
-							Style:       discordgo.TextInputShort,
-							Placeholder: fmt.Sprintf("%v", targetDef.Default),
-							Required:    true,
-						},
-					},
+					Components: []discordgo.MessageComponent{input},
 				},
 			},
 		},
@@ -293,3 +317,19 @@ func updateSetting(s *discordgo.Session, i *discordgo.InteractionCreate, key, va
 		Data: GetGroupPageData(s, i, targetDef.Module),
 	})
 }
+
+// summarizeText shortens a multi-line value for display in an embed field
+func summarizeText(val string) string {
+	if val == "" {
+		return val
+	}
+	lines := strings.Split(strings.TrimSpace(val), "\n")
+	first := []rune(lines[0])
+	if len(first) > 40 {
+		first = append(first[:39], '…')
+	}
+	if len(lines) > 1 {
+		return fmt.Sprintf("%s (+%d)", string(first), len(lines)-1)
+	}
+	return string(first)
+}
@@ -32,6 +32,10 @@ func (c *Command) Version() string {
 	return "1.0.2"
 }
 
+func (c *Command) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryGeneral}
+}
+
 func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	data := GetMainPageData(i)
 	if data == nil {
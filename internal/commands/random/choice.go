@@ -0,0 +1,272 @@
+package random
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	minChoiceItems     = 2
+	maxChoiceItems     = 40   // Keeps the result and chance lists within Discord's embed limits
+	maxItemLength      = 50   // Characters per item label
+	maxItemsInputLen   = 4000 // Characters in the items option or modal field
+	maxWeightedDraws   = 100
+	choiceModalPrefix  = "random_list_"
+	choiceItemsInputID = "items"
+)
+
+// choiceItem is a list entry with its relative weight
+type choiceItem struct {
+	Label  string
+	Weight float64
+}
+
+// parseChoiceItems splits a comma or newline separated list into items.
+// An item may end with ":weight" to set its relative weight (default 1).
+func parseChoiceItems(input string) ([]choiceItem, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '，'
+	})
+
+	items := make([]choiceItem, 0, len(fields))
+	for _, field := range fields {
+		label := strings.TrimSpace(field)
+		if label == "" {
+			continue
+		}
+
+		// Only a numeric suffix is a weight, so labels like "Re:Zero" stay intact
+		weight := 1.0
+		if idx := strings.LastIndex(label, ":"); idx >= 0 {
+			if value, err := strconv.ParseFloat(strings.TrimSpace(label[idx+1:]), 64); err == nil {
+				if value <= 0 || math.IsInf(value, 0) || math.IsNaN(value) || strings.TrimSpace(label[:idx]) == "" {
+					return nil, newUserError("command.random.choice.error.invalid_weight", label)
+				}
+				weight = value
+				label = strings.TrimSpace(label[:idx])
+			}
+		}
+
+		if len([]rune(label)) > maxItemLength {
+			return nil, newUserError("command.random.choice.error.item_too_long", maxItemLength)
+		}
+		items = append(items, choiceItem{Label: label, Weight: weight})
+	}
+
+	if len(items) < minChoiceItems {
+		return nil, newUserError("command.random.choice.error.too_few", minChoiceItems)
+	}
+	if len(items) > maxChoiceItems {
+		return nil, newUserError("command.random.choice.error.too_many", maxChoiceItems)
+	}
+	return items, nil
+}
+
+// weightedOrder returns the items in a random order where each position is drawn
+// from the remaining items proportionally to their weights. With equal weights this
+// is a uniform shuffle.
+func weightedOrder(items []choiceItem, r *rand.Rand) []choiceItem {
+	remaining := make([]choiceItem, len(items))
+	copy(remaining, items)
+
+	order := make([]choiceItem, 0, len(items))
+	for len(remaining) > 0 {
+		idx := weightedIndex(remaining, r)
+		order = append(order, remaining[idx])
+		remaining = append(remaining[:idx], remaining[idx+1:]...)
+	}
+	return order
+}
+
+// weightedIndex draws one index proportionally to the item weights
+func weightedIndex(items []choiceItem, r *rand.Rand) int {
+	target := r.Float64() * totalWeight(items)
+	for idx, item := range items {
+		target -= item.Weight
+		if target < 0 {
+			return idx
+		}
+	}
+	return len(items) - 1
+}
+
+func totalWeight(items []choiceItem) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.Weight
+	}
+	return total
+}
+
+// getChoiceOptions reads the items and count options of the pick, shuffle and weighted subcommands
+func getChoiceOptions(options []*discordgo.ApplicationCommandInteractionDataOption) (string, int64) {
+	input := ""
+	count := int64(1)
+	for _, opt := range options {
+		switch opt.Name {
+		case "items":
+			input = strings.TrimSpace(opt.StringValue())
+		case "count":
+			count = opt.IntValue()
+		}
+	}
+	return input, count
+}
+
+// showChoiceModal opens a modal to enter a longer list.
+// The seed options are carried in the custom ID so the draw can use them on submit.
+func showChoiceModal(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, subcommand string, count int64, seed string, verifiable bool) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			// random_list_{subcommand}_{count}_{verifiable}_{seed}
+			CustomID: fmt.Sprintf("%s%s_%d_%t_%s", choiceModalPrefix, subcommand, count, verifiable, seed),
+			Title:    i18n.T(locale, "command.random.choice.modal.title."+subcommand),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    choiceItemsInputID,
+							Label:       i18n.T(locale, "command.random.choice.modal.label"),
+							Style:       discordgo.TextInputParagraph,
+							Placeholder: i18n.T(locale, "command.random.choice.modal.placeholder"),
+							Required:    true,
+							MaxLength:   maxItemsInputLen,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// HandleChoiceModalSubmit handles the list entered in the choice modal
+func HandleChoiceModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	data := i.ModalSubmitData()
+
+	// Parse CustomID: random_list_{subcommand}_{count}_{verifiable}_{seed}; the seed may contain underscores
+	parts := strings.SplitN(strings.TrimPrefix(data.CustomID, choiceModalPrefix), "_", 4)
+	if len(parts) != 4 {
+		return interactions.RespondError(s, i, locale, "command.execution_error", true)
+	}
+	subcommand := parts[0]
+	count, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return interactions.RespondError(s, i, locale, "command.execution_error", true)
+	}
+	verifiable := parts[2] == "true"
+	seed := parts[3]
+
+	var input string
+	for _, row := range data.Components {
+		if actionRow, ok := row.(*discordgo.ActionsRow); ok {
+			for _, component := range actionRow.Components {
+				if textInput, ok := component.(*discordgo.TextInput); ok && textInput.CustomID == choiceItemsInputID {
+					input = textInput.Value
+				}
+			}
+		}
+	}
+
+	draw, err := prepareChoice(locale, subcommand, input, count)
+	if err != nil {
+		return respondDrawError(s, i, locale, false, err)
+	}
+	return runDraw(s, i, locale, seed, verifiable, false, draw)
+}
+
+// prepareChoice parses the list; the draw picks, shuffles or draws from it and shows the chances
+func prepareChoice(locale i18n.SupportedLocale, subcommand, input string, count int64) (drawFunc, error) {
+	items, err := parseChoiceItems(input)
+	if err != nil {
+		return nil, err
+	}
+
+	switch subcommand {
+	case "pick":
+		if count < 1 || count > int64(len(items)) {
+			return nil, newUserError("command.random.choice.error.count_too_large", len(items))
+		}
+	case "weighted":
+		if count < 1 || count > maxWeightedDraws {
+			count = 1
+		}
+	case "shuffle":
+	default:
+		return nil, newUserError("command.unknown")
+	}
+
+	return func(r *rand.Rand) (*discordgo.InteractionResponseData, error) {
+		var result string
+		switch subcommand {
+		case "pick":
+			picked := weightedOrder(items, r)[:count]
+			labels := make([]string, len(picked))
+			for idx, item := range picked {
+				labels[idx] = "**" + item.Label + "**"
+			}
+			result = strings.Join(labels, ", ")
+
+		case "shuffle":
+			var builder strings.Builder
+			for idx, item := range weightedOrder(items, r) {
+				fmt.Fprintf(&builder, "`%2d.` %s\n", idx+1, item.Label)
+			}
+			result = builder.String()
+
+		case "weighted":
+			tally := make([]int, len(items))
+			for draw := int64(0); draw < count; draw++ {
+				tally[weightedIndex(items, r)]++
+			}
+
+			var builder strings.Builder
+			for idx, item := range items {
+				if tally[idx] == 0 {
+					continue
+				}
+				if count == 1 {
+					builder.WriteString("**" + item.Label + "**")
+					break
+				}
+				builder.WriteString(i18n.Tf(locale, "command.random.choice.tally", item.Label, tally[idx]) + "\n")
+			}
+			result = builder.String()
+		}
+
+		return &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       i18n.T(locale, "command.random.choice.title."+subcommand),
+					Description: result,
+					Color:       0x3498DB,
+				},
+				{
+					Title:       i18n.T(locale, "command.random.choice.chances_title"),
+					Description: formatChances(items),
+					Color:       0x95A5A6,
+				},
+			},
+		}, nil
+	}, nil
+}
+
+// formatChances lists each item's chance of being drawn in a single draw
+func formatChances(items []choiceItem) string {
+	total := totalWeight(items)
+
+	var builder strings.Builder
+	for _, item := range items {
+		fmt.Fprintf(&builder, "%s — %.1f%%\n", item.Label, item.Weight/total*100)
+	}
+	return builder.String()
+}
@@ -0,0 +1,20 @@
+package dice
+
+import "fmt"
+
+// Error is a parse or evaluation error. Code identifies the problem so callers
+// can show a localized message; Args holds the values for that message.
+type Error struct {
+	Pos  int
+	Code string
+	Args []interface{}
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return fmt.Sprintf("dice: %s at position %d %v", e.Code, e.Pos+1, e.Args)
+}
+
+func newError(pos int, code string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Code: code, Args: args}
+}
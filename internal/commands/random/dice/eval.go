@@ -0,0 +1,279 @@
+package dice
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Limits that keep expressions cheap to evaluate and results short enough to post
+const (
+	MaxExpressionLength = 200       // Characters in an expression
+	MaxNestingDepth     = 20        // Nested groups and unary minus
+	MaxDicePerTerm      = 100       // Dice in a single term before explosions
+	MaxSides            = 1000      // Faces per die
+	MaxTotalDice        = 200       // Dice rolled across the whole expression, explosions included
+	MaxExplosions       = 20        // Extra dice a single die can explode into
+	maxNumber           = 1_000_000 // Largest numeric literal
+	maxResult           = 1 << 50   // Largest intermediate result magnitude
+)
+
+// Result is the outcome of rolling an expression
+type Result struct {
+	Total     int64
+	Breakdown string // Expression with each dice term replaced by its individual rolls
+}
+
+// evalContext carries the RNG and running counters through an evaluation
+type evalContext struct {
+	rng        *rand.Rand
+	diceRolled int
+}
+
+// die is a single rolled die
+type die struct {
+	value    int64
+	exploded bool // Rolled the maximum and triggered another die
+	dropped  bool // Removed by a keep/drop modifier
+	success  bool // Matched the success comparison
+}
+
+// Roll parses and evaluates a dice expression using the given RNG
+func Roll(expr string, rng *rand.Rand) (*Result, error) {
+	node, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	return Evaluate(node, rng)
+}
+
+// Evaluate rolls a parsed expression using the given RNG
+func Evaluate(node Node, rng *rand.Rand) (*Result, error) {
+	ctx := &evalContext{rng: rng}
+	total, breakdown, err := node.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Total: total, Breakdown: breakdown}, nil
+}
+
+func (n *NumberNode) eval(ctx *evalContext) (int64, string, error) {
+	return n.Value, strconv.FormatInt(n.Value, 10), nil
+}
+
+func (n *GroupNode) eval(ctx *evalContext) (int64, string, error) {
+	value, text, err := n.Inner.eval(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	return value, "(" + text + ")", nil
+}
+
+func (n *NegateNode) eval(ctx *evalContext) (int64, string, error) {
+	value, text, err := n.Operand.eval(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	return -value, "-" + text, nil
+}
+
+func (n *BinaryNode) eval(ctx *evalContext) (int64, string, error) {
+	left, leftText, err := n.Left.eval(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	right, rightText, err := n.Right.eval(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+
+	var value int64
+	switch n.Op {
+	case "+":
+		value = left + right
+	case "-":
+		value = left - right
+	case "*":
+		if left != 0 && abs(right) > maxResult/abs(left) {
+			return 0, "", newError(n.Pos, "result_too_large")
+		}
+		value = left * right
+	case "/":
+		if right == 0 {
+			return 0, "", newError(n.Pos, "division_by_zero")
+		}
+		value = floorDiv(left, right)
+	}
+
+	if abs(value) > maxResult {
+		return 0, "", newError(n.Pos, "result_too_large")
+	}
+	return value, leftText + " " + n.Op + " " + rightText, nil
+}
+
+func (n *DiceNode) eval(ctx *evalContext) (int64, string, error) {
+	dice := make([]*die, 0, n.Count)
+
+	for idx := int64(0); idx < n.Count; idx++ {
+		d, err := n.rollOne(ctx)
+		if err != nil {
+			return 0, "", err
+		}
+		dice = append(dice, d)
+
+		// Each maximum roll adds another die, up to MaxExplosions per original die
+		for chain := 0; n.Explode && d.value == n.Sides && chain < MaxExplosions; chain++ {
+			d.exploded = true
+			if d, err = n.rollOne(ctx); err != nil {
+				return 0, "", err
+			}
+			dice = append(dice, d)
+		}
+	}
+
+	n.applyKeep(dice)
+
+	var total int64
+	for _, d := range dice {
+		if d.dropped {
+			continue
+		}
+		if n.CompareOp != "" {
+			d.success = compare(d.value, n.CompareOp, n.CompareWith)
+			if d.success {
+				total++
+			}
+			continue
+		}
+		total += d.value
+	}
+
+	return total, n.String() + " " + n.render(dice), nil
+}
+
+// rollOne rolls a single die, enforcing the total dice limit
+func (n *DiceNode) rollOne(ctx *evalContext) (*die, error) {
+	ctx.diceRolled++
+	if ctx.diceRolled > MaxTotalDice {
+		return nil, newError(n.Pos, "too_many_total_dice", MaxTotalDice)
+	}
+
+	if n.Fudge {
+		return &die{value: ctx.rng.Int63n(3) - 1}, nil
+	}
+	return &die{value: ctx.rng.Int63n(n.Sides) + 1}, nil
+}
+
+// applyKeep marks the dice removed by the keep/drop modifier
+func (n *DiceNode) applyKeep(dice []*die) {
+	if n.KeepMode == "" {
+		return
+	}
+
+	// Sort a copy from highest to lowest; ties keep roll order
+	sorted := make([]*die, len(dice))
+	copy(sorted, dice)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].value > sorted[b].value
+	})
+
+	count := int(n.KeepCount)
+	if count > len(sorted) {
+		count = len(sorted)
+	}
+
+	var drop []*die
+	switch n.KeepMode {
+	case "kh":
+		drop = sorted[count:]
+	case "kl":
+		drop = sorted[:len(sorted)-count]
+	case "dh":
+		drop = sorted[:count]
+	case "dl":
+		drop = sorted[len(sorted)-count:]
+	}
+
+	for _, d := range drop {
+		d.dropped = true
+	}
+}
+
+// render formats the individual rolls, e.g. [6!, 3, ~~2~~, **5**]
+func (n *DiceNode) render(dice []*die) string {
+	parts := make([]string, len(dice))
+	for idx, d := range dice {
+		text := strconv.FormatInt(d.value, 10)
+		if n.Fudge {
+			text = [...]string{"-", "0", "+"}[d.value+1]
+		}
+		if d.exploded {
+			text += "!"
+		}
+
+		switch {
+		case d.dropped:
+			text = "~~" + text + "~~"
+		case d.success:
+			text = "**" + text + "**"
+		}
+		parts[idx] = text
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// String returns the canonical notation of the dice term
+func (n *DiceNode) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "%dd", n.Count)
+	if n.Fudge {
+		builder.WriteString("F")
+	} else {
+		builder.WriteString(strconv.FormatInt(n.Sides, 10))
+	}
+	if n.Explode {
+		builder.WriteString("!")
+	}
+	if n.KeepMode != "" {
+		fmt.Fprintf(&builder, "%s%d", n.KeepMode, n.KeepCount)
+	}
+	if n.CompareOp != "" {
+		fmt.Fprintf(&builder, "%s%d", n.CompareOp, n.CompareWith)
+	}
+	return builder.String()
+}
+
+// compare evaluates a success comparison
+func compare(value int64, op string, target int64) bool {
+	switch op {
+	case "<":
+		return value < target
+	case "<=":
+		return value <= target
+	case ">":
+		return value > target
+	case ">=":
+		return value >= target
+	default:
+		return value == target
+	}
+}
+
+// floorDiv divides rounding towards negative infinity, so -7/2 is -4
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,102 @@
+package dice
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestRollTotals(t *testing.T) {
+	tests := []struct {
+		expr  string
+		total int64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"-7 / 2", -4},
+		{"7 / -2", -4},
+		{"--3", 3},
+		{"10d1", 10},
+		{"10d1kh3", 3},
+		{"10d1dl4", 6},
+		{"10d1>=1", 10},
+		{"10d1<1", 0},
+		{"4d1 + 4d1 * 2", 12},
+	}
+
+	for _, tt := range tests {
+		result, err := Roll(tt.expr, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Errorf("Roll(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if result.Total != tt.total {
+			t.Errorf("Roll(%q) = %d, want %d", tt.expr, result.Total, tt.total)
+		}
+	}
+}
+
+func TestRollRanges(t *testing.T) {
+	tests := []struct {
+		expr     string
+		min, max int64
+	}{
+		{"d6", 1, 6},
+		{"3d6", 3, 18},
+		{"d%", 1, 100},
+		{"4dF", -4, 4},
+		{"4d6kh3", 3, 18},
+		{"10d10>=7", 0, 10},
+	}
+
+	rng := rand.New(rand.NewSource(42))
+	for _, tt := range tests {
+		for n := 0; n < 200; n++ {
+			result, err := Roll(tt.expr, rng)
+			if err != nil {
+				t.Fatalf("Roll(%q) error = %v", tt.expr, err)
+			}
+			if result.Total < tt.min || result.Total > tt.max {
+				t.Fatalf("Roll(%q) = %d, want %d..%d", tt.expr, result.Total, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestRollSameSeed(t *testing.T) {
+	first, err := Roll("8d20!kh4 + 2d6", rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Roll("8d20!kh4 + 2d6", rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *first != *second {
+		t.Errorf("same seed gave %+v and %+v", first, second)
+	}
+}
+
+func TestRollLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		code string
+	}{
+		{"division by zero", "1 / (2 - 2)", "division_by_zero"},
+		{"total dice", "100d6 + 100d6 + 1d6", "too_many_total_dice"},
+		{"explosions count", "100d2! + 100d2!", "too_many_total_dice"},
+		{"product too large", "1000000 * 1000000 * 1000000", "result_too_large"},
+		{"sum too large", "1000000 * 1000000 * 1000 + 1000000 * 1000000 * 1000", "result_too_large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Roll(tt.expr, rand.New(rand.NewSource(1)))
+			var diceErr *Error
+			if !errors.As(err, &diceErr) || diceErr.Code != tt.code {
+				t.Errorf("Roll(%q) error = %v, want code %s", tt.expr, err, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package dice
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// TokenType identifies the kind of a lexical token
+type TokenType int
+
+const (
+	TokenEOF TokenType = iota
+	TokenNumber
+	TokenDice    // d
+	TokenPercent // % (as in d%)
+	TokenFudge   // F (as in dF)
+	TokenKeep    // k, kh, kl
+	TokenDrop    // dh, dl
+	TokenExplode // !
+	TokenCompare // <, <=, >, >=, =
+	TokenPlus
+	TokenMinus
+	TokenStar
+	TokenSlash
+	TokenLParen
+	TokenRParen
+)
+
+// Token is a single lexical token of a dice expression
+type Token struct {
+	Type  TokenType
+	Text  string
+	Value int64 // Numeric value for TokenNumber
+	Pos   int   // Character offset in the expression
+}
+
+// Tokenize splits a dice expression into tokens
+func Tokenize(expr string) ([]Token, error) {
+	runes := []rune(expr)
+	var tokens []Token
+
+	for pos := 0; pos < len(runes); {
+		r := runes[pos]
+		start := pos
+
+		switch {
+		case unicode.IsSpace(r):
+			pos++
+			continue
+
+		case r >= '0' && r <= '9':
+			var value int64
+			for pos < len(runes) && runes[pos] >= '0' && runes[pos] <= '9' {
+				value = value*10 + int64(runes[pos]-'0')
+				if value > maxNumber {
+					return nil, newError(start, "number_too_large", maxNumber)
+				}
+				pos++
+			}
+			tokens = append(tokens, Token{Type: TokenNumber, Text: string(runes[start:pos]), Value: value, Pos: start})
+			continue
+
+		case r == 'd' || r == 'D':
+			// "dh"/"dl" drop the highest/lowest dice, anything else starts a dice term
+			if pos+1 < len(runes) && strings.ContainsRune("hlHL", runes[pos+1]) {
+				tokens = append(tokens, Token{Type: TokenDrop, Text: strings.ToLower(string(runes[pos : pos+2])), Pos: start})
+				pos += 2
+				continue
+			}
+			tokens = append(tokens, Token{Type: TokenDice, Text: "d", Pos: start})
+
+		case r == 'k' || r == 'K':
+			// A bare "k" keeps the highest dice
+			text := "kh"
+			if pos+1 < len(runes) && strings.ContainsRune("hlHL", runes[pos+1]) {
+				text = strings.ToLower(string(runes[pos : pos+2]))
+				pos++
+			}
+			tokens = append(tokens, Token{Type: TokenKeep, Text: text, Pos: start})
+
+		case r == 'f' || r == 'F':
+			tokens = append(tokens, Token{Type: TokenFudge, Text: "F", Pos: start})
+
+		case r == '%':
+			tokens = append(tokens, Token{Type: TokenPercent, Text: "%", Pos: start})
+
+		case r == '!':
+			tokens = append(tokens, Token{Type: TokenExplode, Text: "!", Pos: start})
+
+		case r == '<' || r == '>':
+			text := string(r)
+			if pos+1 < len(runes) && runes[pos+1] == '=' {
+				text += "="
+				pos++
+			}
+			tokens = append(tokens, Token{Type: TokenCompare, Text: text, Pos: start})
+
+		case r == '=':
+			tokens = append(tokens, Token{Type: TokenCompare, Text: "=", Pos: start})
+
+		case r == '+':
+			tokens = append(tokens, Token{Type: TokenPlus, Text: "+", Pos: start})
+
+		case r == '-':
+			tokens = append(tokens, Token{Type: TokenMinus, Text: "-", Pos: start})
+
+		case r == '*' || r == 'x' || r == '×':
+			tokens = append(tokens, Token{Type: TokenStar, Text: "*", Pos: start})
+
+		case r == '/' || r == '÷':
+			tokens = append(tokens, Token{Type: TokenSlash, Text: "/", Pos: start})
+
+		case r == '(':
+			tokens = append(tokens, Token{Type: TokenLParen, Text: "(", Pos: start})
+
+		case r == ')':
+			tokens = append(tokens, Token{Type: TokenRParen, Text: ")", Pos: start})
+
+		default:
+			return nil, newError(start, "unexpected_char", string(r), start+1)
+		}
+
+		pos++
+	}
+
+	tokens = append(tokens, Token{Type: TokenEOF, Pos: len(runes)})
+	return tokens, nil
+}
+
+// String returns a readable representation of the token for debugging
+func (t Token) String() string {
+	if t.Type == TokenEOF {
+		return "EOF"
+	}
+	return fmt.Sprintf("%q@%d", t.Text, t.Pos)
+}
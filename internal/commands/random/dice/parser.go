@@ -0,0 +1,284 @@
+package dice
+
+// Node is a node of a parsed dice expression
+type Node interface {
+	eval(ctx *evalContext) (int64, string, error)
+}
+
+// NumberNode is an integer literal
+type NumberNode struct {
+	Value int64
+}
+
+// DiceNode is a dice term such as 4d6kh3, d20!, 3dF or 10d10>=7
+type DiceNode struct {
+	Count       int64
+	Sides       int64 // Zero for fudge dice
+	Fudge       bool
+	Explode     bool
+	KeepMode    string // "kh", "kl", "dh", "dl" or empty
+	KeepCount   int64
+	CompareOp   string // Success counting operator, or empty
+	CompareWith int64
+	Pos         int
+}
+
+// BinaryNode is an arithmetic operation
+type BinaryNode struct {
+	Op    string
+	Left  Node
+	Right Node
+	Pos   int
+}
+
+// NegateNode is a unary minus
+type NegateNode struct {
+	Operand Node
+}
+
+// GroupNode is a parenthesized expression
+type GroupNode struct {
+	Inner Node
+}
+
+// parser is a recursive descent parser over the token stream
+type parser struct {
+	tokens []Token
+	pos    int
+	depth  int
+}
+
+// Parse parses a dice expression into a syntax tree.
+//
+// Grammar:
+//
+//	expr    := term (("+" | "-") term)*
+//	term    := unary (("*" | "/") unary)*
+//	unary   := "-" unary | primary
+//	primary := NUMBER | dice | "(" expr ")"
+//	dice    := [NUMBER] "d" (NUMBER | "%" | "F") modifier*
+//	modifier:= "!" | ("k" | "kh" | "kl" | "dh" | "dl") NUMBER | COMPARE NUMBER
+func Parse(expr string) (Node, error) {
+	if len([]rune(expr)) > MaxExpressionLength {
+		return nil, newError(0, "too_long", MaxExpressionLength)
+	}
+
+	tokens, err := Tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if tokens[0].Type == TokenEOF {
+		return nil, newError(0, "empty")
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.Type != TokenEOF {
+		return nil, newError(tok.Pos, "unexpected_token", tok.Text, tok.Pos+1)
+	}
+	return node, nil
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() Token {
+	tok := p.tokens[p.pos]
+	if tok.Type != TokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) parseExpr() (Node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > MaxNestingDepth {
+		return nil, newError(p.peek().Pos, "too_deep", MaxNestingDepth)
+	}
+
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.Type != TokenPlus && tok.Type != TokenMinus {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryNode{Op: tok.Text, Left: left, Right: right, Pos: tok.Pos}
+	}
+}
+
+func (p *parser) parseTerm() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.Type != TokenStar && tok.Type != TokenSlash {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryNode{Op: tok.Text, Left: left, Right: right, Pos: tok.Pos}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if p.peek().Type == TokenMinus {
+		p.next()
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > MaxNestingDepth {
+			return nil, newError(p.peek().Pos, "too_deep", MaxNestingDepth)
+		}
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &NegateNode{Operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.peek()
+
+	switch tok.Type {
+	case TokenNumber:
+		p.next()
+		if p.peek().Type == TokenDice {
+			return p.parseDice(tok.Value, tok.Pos)
+		}
+		return &NumberNode{Value: tok.Value}, nil
+
+	case TokenDice:
+		return p.parseDice(1, tok.Pos)
+
+	case TokenLParen:
+		p.next()
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.Type != TokenRParen {
+			return nil, unexpected(closing)
+		}
+		return &GroupNode{Inner: inner}, nil
+	}
+
+	return nil, unexpected(tok)
+}
+
+// parseDice parses a dice term starting at the "d" token
+func (p *parser) parseDice(count int64, pos int) (Node, error) {
+	p.next() // "d"
+
+	node := &DiceNode{Count: count, Pos: pos}
+
+	sides := p.next()
+	switch sides.Type {
+	case TokenNumber:
+		node.Sides = sides.Value
+	case TokenPercent:
+		node.Sides = 100
+	case TokenFudge:
+		node.Fudge = true
+	default:
+		return nil, unexpected(sides)
+	}
+
+	if err := validateDice(node); err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		switch tok.Type {
+		case TokenExplode:
+			p.next()
+			if node.Fudge || node.Sides < 2 {
+				return nil, newError(tok.Pos, "invalid_explode")
+			}
+			node.Explode = true
+
+		case TokenKeep, TokenDrop:
+			p.next()
+			if node.KeepMode != "" {
+				return nil, newError(tok.Pos, "duplicate_modifier", tok.Text)
+			}
+			amount := p.next()
+			if amount.Type != TokenNumber {
+				return nil, unexpected(amount)
+			}
+			if amount.Value < 1 || amount.Value > node.Count {
+				return nil, newError(amount.Pos, "invalid_keep", tok.Text, node.Count)
+			}
+			node.KeepMode = tok.Text
+			node.KeepCount = amount.Value
+
+		case TokenCompare:
+			p.next()
+			if node.CompareOp != "" {
+				return nil, newError(tok.Pos, "duplicate_modifier", tok.Text)
+			}
+			target := p.next()
+			if target.Type != TokenNumber {
+				return nil, unexpected(target)
+			}
+			node.CompareOp = tok.Text
+			node.CompareWith = target.Value
+
+		default:
+			return node, nil
+		}
+	}
+}
+
+// validateDice checks the dice count and sides against the abuse limits
+func validateDice(node *DiceNode) error {
+	if node.Count < 1 {
+		return newError(node.Pos, "too_few_dice")
+	}
+	if node.Count > MaxDicePerTerm {
+		return newError(node.Pos, "too_many_dice", MaxDicePerTerm)
+	}
+	if node.Fudge {
+		return nil
+	}
+	if node.Sides < 1 {
+		return newError(node.Pos, "too_few_sides")
+	}
+	if node.Sides > MaxSides {
+		return newError(node.Pos, "too_many_sides", MaxSides)
+	}
+	return nil
+}
+
+// unexpected builds the error for an unexpected token
+func unexpected(tok Token) error {
+	if tok.Type == TokenEOF {
+		return newError(tok.Pos, "unexpected_end")
+	}
+	return newError(tok.Pos, "unexpected_token", tok.Text, tok.Pos+1)
+}
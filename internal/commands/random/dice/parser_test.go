@@ -0,0 +1,77 @@
+package dice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		code string
+		pos  int
+	}{
+		{"empty", "", "empty", 0},
+		{"only spaces", "   ", "empty", 0},
+		{"too long", strings.Repeat("1+", 100) + "1", "too_long", 0},
+		{"unexpected char", "2d6 + a", "unexpected_char", 6},
+		{"number too large", "1000001", "number_too_large", 0},
+		{"missing sides", "2d", "unexpected_end", 2},
+		{"bad sides", "2d+", "unexpected_token", 2},
+		{"trailing operator", "1+", "unexpected_end", 2},
+		{"unclosed group", "(1+2", "unexpected_end", 4},
+		{"stray closing", "1)", "unexpected_token", 1},
+		{"zero dice", "0d6", "too_few_dice", 0},
+		{"too many dice", "101d6", "too_many_dice", 0},
+		{"zero sides", "d0", "too_few_sides", 0},
+		{"too many sides", "d1001", "too_many_sides", 0},
+		{"explode fudge", "4dF!", "invalid_explode", 3},
+		{"explode one side", "3d1!", "invalid_explode", 3},
+		{"keep more than rolled", "2d6k3", "invalid_keep", 4},
+		{"keep zero", "2d6kl0", "invalid_keep", 5},
+		{"two keep modifiers", "4d6kh3dl1", "duplicate_modifier", 6},
+		{"two comparisons", "5d10>5<9", "duplicate_modifier", 6},
+		{"keep without amount", "4d6k", "unexpected_end", 4},
+		{"too deep", strings.Repeat("(", 20) + "1" + strings.Repeat(")", 20), "too_deep", 20},
+		{"too many minus signs", strings.Repeat("-", 20) + "1", "too_deep", 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			var diceErr *Error
+			if !errors.As(err, &diceErr) {
+				t.Fatalf("Parse(%q) error = %v, want code %s", tt.expr, err, tt.code)
+			}
+			if diceErr.Code != tt.code || diceErr.Pos != tt.pos {
+				t.Errorf("Parse(%q) = %s at %d, want %s at %d", tt.expr, diceErr.Code, diceErr.Pos, tt.code, tt.pos)
+			}
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	valid := []string{
+		"100d6",
+		"d1000",
+		"1000000",
+		"1" + strings.Repeat(" ", 199),
+		strings.Repeat("(", 19) + "1" + strings.Repeat(")", 19),
+		strings.Repeat("-", 19) + "1",
+		"4d6kh3",
+		"4d6k4",
+		"4dF",
+		"d%",
+		"10d10>=7",
+		"3d6! * 2 - (d4 / 2)",
+		"2D6 × 3 ÷ 2",
+	}
+
+	for _, expr := range valid {
+		if _, err := Parse(expr); err != nil {
+			t.Errorf("Parse(%q) error = %v", expr, err)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package random
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	mathrand "math/rand"
+
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	maxSeedLength = 64
+)
+
+// drawFunc produces a result from the given source of randomness.
+// Input is validated before a drawFunc is returned, so that verifiable
+// draws can report problems before publishing a commitment.
+type drawFunc func(r *mathrand.Rand) (*discordgo.InteractionResponseData, error)
+
+// userError is a localized error shown to the user instead of a result
+type userError struct {
+	Key  string
+	Args []interface{}
+}
+
+func newUserError(key string, args ...interface{}) *userError {
+	return &userError{Key: key, Args: args}
+}
+
+func (e *userError) Error() string {
+	return e.Key
+}
+
+// seedOptions returns the options shared by every draw subcommand
+func seedOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "seed",
+			Description: "Seed to reproduce a previous result",
+			Required:    false,
+			MaxLength:   maxSeedLength,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "verifiable",
+			Description: "Publish a commitment first and reveal the server seed with the result",
+			Required:    false,
+		},
+	}
+}
+
+// getSeedOptions reads the seed and verifiable options
+func getSeedOptions(options []*discordgo.ApplicationCommandInteractionDataOption) (string, bool) {
+	seed := ""
+	verifiable := false
+	for _, opt := range options {
+		switch opt.Name {
+		case "seed":
+			seed = opt.StringValue()
+		case "verifiable":
+			verifiable = opt.BoolValue()
+		}
+	}
+	return seed, verifiable
+}
+
+// newSeed generates a random seed for draws without one
+func newSeed() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand never fails on supported platforms
+	}
+	return hex.EncodeToString(b)
+}
+
+// newRand creates the deterministic generator for a seed: the first 8 bytes
+// of SHA-256(seed), read as a big-endian integer, seed math/rand
+func newRand(seed string) *mathrand.Rand {
+	sum := sha256.Sum256([]byte(seed))
+	return mathrand.New(mathrand.NewSource(int64(binary.BigEndian.Uint64(sum[:8]))))
+}
+
+// deferDraw acknowledges the interaction for draws that need longer to prepare
+// than Discord waits for a reply; the deferred reply is then edited instead
+func deferDraw(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+}
+
+// runDraw performs a prepared draw and replies with the result.
+//
+// Regular draws show the seed so the result can be reproduced with the seed option.
+// Verifiable draws first reply with the commitment, then post the result and reveal
+// the server seed in a follow-up message.
+func runDraw(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, seed string, verifiable, deferred bool, draw drawFunc) error {
+	if verifiable {
+		return runVerifiableDraw(s, i, locale, seed, deferred, draw)
+	}
+
+	if seed == "" {
+		seed = newSeed()
+	}
+
+	data, err := draw(newRand(seed))
+	if err != nil {
+		return respondDrawError(s, i, locale, deferred, err)
+	}
+	data.Content = appendLine(data.Content, i18n.Tf(locale, "command.random.seed", seed))
+
+	if deferred {
+		_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content:         &data.Content,
+			Embeds:          &data.Embeds,
+			Components:      &data.Components,
+			AllowedMentions: data.AllowedMentions,
+		})
+		return err
+	}
+	return interactions.RespondCustom(s, i, data)
+}
+
+// runVerifiableDraw performs a draw with the commit-reveal scheme.
+// The client seed is the ID of the commitment message, combined with the user's
+// seed when one is given, so the server cannot pick it before committing.
+func runVerifiableDraw(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, userSeed string, deferred bool, draw drawFunc) error {
+	serverSeed := newServerSeed()
+	commitment := commit(serverSeed)
+	content := i18n.Tf(locale, "command.random.verify.commitment", commitment)
+
+	var message *discordgo.Message
+	var err error
+	if deferred {
+		message, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: &content,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: content,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		message, err = s.InteractionResponse(i.Interaction)
+		if err != nil {
+			return err
+		}
+	}
+
+	clientSeed := message.ID
+	if userSeed != "" {
+		clientSeed = userSeed + ":" + message.ID
+	}
+	drawSeed := deriveSeed(serverSeed, clientSeed)
+
+	data, err := draw(newRand(drawSeed))
+	if err != nil {
+		if userErr, ok := err.(*userError); ok {
+			_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+				Content: i18n.T(locale, "common.error_prefix") + " " + i18n.Tf(locale, userErr.Key, userErr.Args...),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			})
+		}
+		return err
+	}
+
+	// The reveal goes in its own embed, so it does not count against the
+	// message length the result was sized for
+	reveal := &discordgo.MessageEmbed{
+		Description: i18n.Tf(locale, "command.random.verify.reveal", commitment, serverSeed, clientSeed, drawSeed),
+		Color:       0x95A5A6,
+	}
+
+	// Re-rolling would bypass the commitment, so verifiable results have no components
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content:         data.Content,
+		Embeds:          append(data.Embeds, reveal),
+		AllowedMentions: data.AllowedMentions,
+		Components:      []discordgo.MessageComponent{},
+	})
+	return err
+}
+
+// respondDrawError replies with a user error, or returns any other error.
+// A deferred reply is replaced with the error instead.
+func respondDrawError(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, deferred bool, err error) error {
+	userErr, ok := err.(*userError)
+	if !ok {
+		return err
+	}
+	if !deferred {
+		return interactions.RespondError(s, i, locale, userErr.Key, true, userErr.Args...)
+	}
+
+	content := i18n.T(locale, "common.error_prefix") + " " + i18n.Tf(locale, userErr.Key, userErr.Args...)
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: &content,
+	})
+	return err
+}
+
+func appendLine(content, line string) string {
+	if content == "" {
+		return line
+	}
+	return content + "\n" + line
+}
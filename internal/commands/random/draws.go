@@ -0,0 +1,74 @@
+package random
+
+import (
+	"sync"
+	"time"
+
+	"hiei-discord-bot/internal/i18n"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// drawTTL is how long a team/member draw can be re-rolled
+	drawTTL = 24 * time.Hour
+)
+
+// Draw is a team split or member pick that can be re-rolled by its owner
+type Draw struct {
+	ID        string
+	OwnerID   string
+	Mode      string   // "teams" or "member"
+	Count     int      // Number of teams or winners
+	Pool      []string // Eligible user IDs
+	Locale    i18n.SupportedLocale
+	CreatedAt time.Time
+}
+
+// DrawStore keeps recent draws in memory for the re-roll button
+type DrawStore struct {
+	draws map[string]*Draw // drawID -> Draw
+	mu    sync.RWMutex
+}
+
+var drawStore *DrawStore
+var drawStoreOnce sync.Once
+
+// GetDrawStore returns the singleton draw store
+func GetDrawStore() *DrawStore {
+	drawStoreOnce.Do(func() {
+		drawStore = &DrawStore{
+			draws: make(map[string]*Draw),
+		}
+	})
+	return drawStore
+}
+
+// Save stores a draw under a new ID and prunes expired draws
+func (d *DrawStore) Save(draw *Draw) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	for id, existing := range d.draws {
+		if now.Sub(existing.CreatedAt) > drawTTL {
+			delete(d.draws, id)
+		}
+	}
+
+	draw.ID = uuid.New().String()
+	draw.CreatedAt = now
+	d.draws[draw.ID] = draw
+}
+
+// Get retrieves a draw that has not expired
+func (d *DrawStore) Get(id string) (*Draw, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	draw, exists := d.draws[id]
+	if !exists || time.Since(draw.CreatedAt) > drawTTL {
+		return nil, false
+	}
+	return draw, true
+}
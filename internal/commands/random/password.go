@@ -0,0 +1,234 @@
+package random
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"log/slog"
+	"math"
+	"math/big"
+	"path"
+	"strings"
+	"sync"
+
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+	"hiei-discord-bot/resources"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	defaultPasswordLength = 20
+	minPasswordLength     = 8
+	maxPasswordLength     = 128
+	defaultPassphraseSize = 6
+	minPassphraseSize     = 3
+	maxPassphraseSize     = 12
+	maxSeparatorLength    = 3
+	passphraseWordlist    = "eff_large_wordlist.txt"
+)
+
+// Character classes for generated passwords
+const (
+	lowercaseChars = "abcdefghijklmnopqrstuvwxyz"
+	uppercaseChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars     = "0123456789"
+	symbolChars    = "!@#$%^&*()-_=+[]{};:,.?/~"
+	ambiguousChars = "Il1O0o"
+)
+
+var errWordlistUnavailable = errors.New("passphrase word list unavailable")
+
+var (
+	wordlist     []string
+	wordlistOnce sync.Once
+)
+
+// getWordlist returns the embedded EFF large word list (7776 words, one per five dice rolls)
+func getWordlist() []string {
+	wordlistOnce.Do(func() {
+		data, err := resources.Wordlists.ReadFile(path.Join(resources.WordlistsBasePath, passphraseWordlist))
+		if err != nil {
+			slog.Error("Failed to load passphrase word list", "error", err)
+			return
+		}
+
+		// Each line is "<dice rolls>\t<word>"
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) == 2 {
+				wordlist = append(wordlist, fields[1])
+			}
+		}
+	})
+	return wordlist
+}
+
+// secureIndex returns a uniformly random index in [0, n) from crypto/rand
+func secureIndex(n int) (int, error) {
+	value, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(value.Int64()), nil
+}
+
+// handlePassword generates a password or passphrase with crypto/rand and always replies ephemerally.
+// It deliberately bypasses the seeded draw flow: a reproducible password is not a secret.
+func handlePassword(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	mode := "password"
+	length := defaultPasswordLength
+	words := defaultPassphraseSize
+	separator := "-"
+	useLower, useUpper, useDigits, useSymbols := true, true, true, true
+	excludeAmbiguous := false
+
+	for _, opt := range options {
+		switch opt.Name {
+		case "mode":
+			mode = opt.StringValue()
+		case "length":
+			length = int(opt.IntValue())
+		case "words":
+			words = int(opt.IntValue())
+		case "separator":
+			separator = opt.StringValue()
+		case "lowercase":
+			useLower = opt.BoolValue()
+		case "uppercase":
+			useUpper = opt.BoolValue()
+		case "digits":
+			useDigits = opt.BoolValue()
+		case "symbols":
+			useSymbols = opt.BoolValue()
+		case "exclude_ambiguous":
+			excludeAmbiguous = opt.BoolValue()
+		}
+	}
+
+	var secret string
+	var entropy float64
+	var err error
+	if mode == "passphrase" {
+		secret, entropy, err = generatePassphrase(words, separator)
+	} else {
+		var classes []string
+		for _, class := range []struct {
+			enabled bool
+			chars   string
+		}{
+			{useLower, lowercaseChars},
+			{useUpper, uppercaseChars},
+			{useDigits, digitChars},
+			{useSymbols, symbolChars},
+		} {
+			if !class.enabled {
+				continue
+			}
+			if excludeAmbiguous {
+				class.chars = removeChars(class.chars, ambiguousChars)
+			}
+			classes = append(classes, class.chars)
+		}
+		if len(classes) == 0 {
+			return interactions.RespondError(s, i, locale, "command.random.password.error.no_classes", true)
+		}
+		secret, entropy, err = generatePassword(length, classes)
+	}
+	if err != nil {
+		return err
+	}
+
+	return interactions.RespondCustom(s, i, &discordgo.InteractionResponseData{
+		Content: i18n.Tf(locale, "command.random.password.result."+mode, secret) + "\n" +
+			i18n.Tf(locale, "command.random.password.entropy", entropy, i18n.T(locale, "command.random.password.strength."+strengthLevel(entropy))),
+		Flags: discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// generatePassword builds a password containing at least one character of every class.
+// Candidates missing a class are rejected and redrawn, which keeps the result uniform
+// over all valid passwords. The entropy estimate is length × log2(alphabet size).
+func generatePassword(length int, classes []string) (string, float64, error) {
+	if length < minPasswordLength || length > maxPasswordLength {
+		length = defaultPasswordLength
+	}
+	alphabet := strings.Join(classes, "")
+
+	for {
+		password := make([]byte, length)
+		for idx := range password {
+			n, err := secureIndex(len(alphabet))
+			if err != nil {
+				return "", 0, err
+			}
+			password[idx] = alphabet[n]
+		}
+
+		if containsEveryClass(string(password), classes) {
+			return string(password), float64(length) * math.Log2(float64(len(alphabet))), nil
+		}
+	}
+}
+
+// generatePassphrase joins random words from the word list.
+// The entropy is words × log2(word list size), about 12.9 bits per word.
+func generatePassphrase(words int, separator string) (string, float64, error) {
+	if words < minPassphraseSize || words > maxPassphraseSize {
+		words = defaultPassphraseSize
+	}
+	if len([]rune(separator)) > maxSeparatorLength {
+		separator = "-"
+	}
+
+	list := getWordlist()
+	if len(list) == 0 {
+		return "", 0, errWordlistUnavailable
+	}
+
+	chosen := make([]string, words)
+	for idx := range chosen {
+		n, err := secureIndex(len(list))
+		if err != nil {
+			return "", 0, err
+		}
+		chosen[idx] = list[n]
+	}
+
+	return strings.Join(chosen, separator), float64(words) * math.Log2(float64(len(list))), nil
+}
+
+// strengthLevel maps an entropy estimate to a strength i18n key suffix
+func strengthLevel(entropy float64) string {
+	switch {
+	case entropy < 50:
+		return "weak"
+	case entropy < 70:
+		return "fair"
+	case entropy < 100:
+		return "strong"
+	default:
+		return "very_strong"
+	}
+}
+
+func containsEveryClass(password string, classes []string) bool {
+	for _, class := range classes {
+		if !strings.ContainsAny(password, class) {
+			return false
+		}
+	}
+	return true
+}
+
+func removeChars(chars, remove string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(remove, r) {
+			return -1
+		}
+		return r
+	}, chars)
+}
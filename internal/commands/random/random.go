@@ -6,7 +6,6 @@ import (
 	"hiei-discord-bot/internal/i18n"
 	"hiei-discord-bot/internal/interactions"
 	"math/rand"
-	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/google/uuid"
@@ -26,7 +25,7 @@ func New() *Command {
 
 // Definition returns the slash command definition
 func (c *Command) Definition() *discordgo.ApplicationCommand {
-	return &discordgo.ApplicationCommand{
+	definition := &discordgo.ApplicationCommand{
 		Name:        "random",
 		Description: "Generate random values",
 		Options: []*discordgo.ApplicationCommandOption{
@@ -82,8 +81,259 @@ func (c *Command) Definition() *discordgo.ApplicationCommand {
 					},
 				},
 			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "roll",
+				Description: "Roll dice using RPG notation (e.g. 4d6kh3, 2d20+5, d6!)",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "expression",
+						Description: "Dice expression, e.g. 4d6kh3, 2d20+5, 1d100<=35, 4dF",
+						Required:    true,
+						MaxLength:   200,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "pick",
+				Description: "Pick items from a list",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "items",
+						Description: "Comma-separated items, optionally item:weight (leave empty to open an input box)",
+						Required:    false,
+						MaxLength:   maxItemsInputLen,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "count",
+						Description: "How many items to pick (default: 1)",
+						Required:    false,
+						MinValue:    float64Ptr(1),
+						MaxValue:    maxChoiceItems,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "shuffle",
+				Description: "Shuffle a list",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "items",
+						Description: "Comma-separated items, optionally item:weight (leave empty to open an input box)",
+						Required:    false,
+						MaxLength:   maxItemsInputLen,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "weighted",
+				Description: "Draw from a list using item:weight odds",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "items",
+						Description: "Comma-separated items, optionally item:weight (leave empty to open an input box)",
+						Required:    false,
+						MaxLength:   maxItemsInputLen,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "count",
+						Description: "How many draws with replacement (default: 1)",
+						Required:    false,
+						MinValue:    float64Ptr(1),
+						MaxValue:    maxWeightedDraws,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "teams",
+				Description: "Split members into balanced teams",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "count",
+						Description: "Number of teams (default: 2)",
+						Required:    false,
+						MinValue:    float64Ptr(2),
+						MaxValue:    maxTeams,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        "role",
+						Description: "Draw from members with this role",
+						Required:    false,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionChannel,
+						Name:         "voice",
+						Description:  "Draw from a voice channel (default: your current voice channel)",
+						Required:     false,
+						ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildVoice, discordgo.ChannelTypeGuildStageVoice},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "members",
+						Description: "Draw from the mentioned members, e.g. @alice @bob @carol",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "member",
+				Description: "Pick random members",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "count",
+						Description: "Number of members to pick (default: 1)",
+						Required:    false,
+						MinValue:    float64Ptr(1),
+						MaxValue:    maxPoolSize,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        "role",
+						Description: "Draw from members with this role",
+						Required:    false,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionChannel,
+						Name:         "voice",
+						Description:  "Draw from a voice channel (default: your current voice channel)",
+						Required:     false,
+						ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildVoice, discordgo.ChannelTypeGuildStageVoice},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "members",
+						Description: "Draw from the mentioned members, e.g. @alice @bob @carol",
+						Required:    false,
+					},
+				},
+			},
 		},
 	}
+
+	// Every draw can be reproduced from a seed or made verifiable
+	for _, subcommand := range definition.Options {
+		subcommand.Options = append(subcommand.Options, seedOptions()...)
+	}
+
+	// Passwords always come from crypto/rand and cannot be seeded
+	definition.Options = append(definition.Options, &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "password",
+		Description: "Generate a secure password or passphrase (only visible to you)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "mode",
+				Description: "Random characters or a diceware-style passphrase (default: password)",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "Password",
+						Value: "password",
+					},
+					{
+						Name:  "Passphrase",
+						Value: "passphrase",
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "length",
+				Description: "Password length (default: 20)",
+				Required:    false,
+				MinValue:    float64Ptr(minPasswordLength),
+				MaxValue:    maxPasswordLength,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "lowercase",
+				Description: "Include lowercase letters (default: true)",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "uppercase",
+				Description: "Include uppercase letters (default: true)",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "digits",
+				Description: "Include digits (default: true)",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "symbols",
+				Description: "Include symbols (default: true)",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "exclude_ambiguous",
+				Description: "Leave out look-alike characters such as I, l, 1, O and 0 (default: false)",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "words",
+				Description: "Number of words in passphrase mode (default: 6)",
+				Required:    false,
+				MinValue:    float64Ptr(minPassphraseSize),
+				MaxValue:    maxPassphraseSize,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "separator",
+				Description: "Word separator in passphrase mode (default: -)",
+				Required:    false,
+				MaxLength:   maxSeparatorLength,
+			},
+		},
+	})
+
+	definition.Options = append(definition.Options, &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "verify",
+		Description: "Verify a verifiable draw and get the seed to reproduce it",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "server_seed",
+				Description: "Server seed revealed with the result",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "client_seed",
+				Description: "Client seed shown with the result",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "commitment",
+				Description: "Commitment published before the draw",
+				Required:    false,
+			},
+		},
+	})
+
+	return definition
 }
 
 func float64Ptr(f float64) *float64 {
@@ -94,7 +344,12 @@ const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
 
 // Version returns the command version
 func (c *Command) Version() string {
-	return "0.0.3"
+	return "0.5.0"
+}
+
+// Describe returns the help metadata
+func (c *Command) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryUtility}
 }
 
 // Execute runs the random command
@@ -106,53 +361,104 @@ func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate)
 	}
 
 	subcommand := data.Options[0]
-	var result string
+	switch subcommand.Name {
+	case "verify":
+		return handleVerify(s, i, locale, subcommand.Options)
+	case "password":
+		return handlePassword(s, i, locale, subcommand.Options)
+	}
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	seed, verifiable := getSeedOptions(subcommand.Options)
 
+	var draw drawFunc
+	var err error
+	deferred := false
 	switch subcommand.Name {
-	case "integer":
-		min := int64(0)
-		max := int64(100)
-		for _, opt := range subcommand.Options {
-			if opt.Name == "min" {
-				min = opt.IntValue()
-			} else if opt.Name == "max" {
-				max = opt.IntValue()
-			}
-		}
-		if min > max {
-			min, max = max, min
+	case "integer", "string", "uuid", "dice":
+		draw = prepareBasic(locale, subcommand)
+	case "roll":
+		draw, err = prepareRoll(locale, subcommand.Options)
+	case "pick", "shuffle", "weighted":
+		input, count := getChoiceOptions(subcommand.Options)
+		if input == "" {
+			// The list is drawn when the modal is submitted
+			return showChoiceModal(s, i, locale, subcommand.Name, count, seed, verifiable)
 		}
-		val := r.Int63n(max-min+1) + min
-		result = fmt.Sprintf("%d (%s)", val, i18n.Tf(locale, "command.random.range.integer", min, max))
-	case "string":
-		length := 8
-		if len(subcommand.Options) > 0 && subcommand.Options[0].Name == "length" {
-			length = int(subcommand.Options[0].IntValue())
+		draw, err = prepareChoice(locale, subcommand.Name, input, count)
+	case "teams", "member":
+		// Listing the members of a role takes a request per 1000 members, which may
+		// take longer than Discord waits for a reply
+		if err := deferDraw(s, i); err != nil {
+			return err
 		}
-		b := make([]byte, length)
-		for i := range b {
-			b[i] = charset[r.Intn(len(charset))]
-		}
-		result = fmt.Sprintf("%s (%s)", string(b), i18n.Tf(locale, "command.random.range.string", length))
-	case "uuid":
-		result = uuid.New().String()
-	case "dice":
-		face := int64(6)
-		for _, opt := range subcommand.Options {
-			if opt.Name == "face" {
-				face = opt.IntValue()
+		deferred = true
+		draw, err = prepareMemberDraw(s, i, locale, subcommand.Name, subcommand.Options)
+	default:
+		return interactions.RespondError(s, i, locale, "command.unknown", true)
+	}
+	if err != nil {
+		return respondDrawError(s, i, locale, deferred, err)
+	}
+
+	return runDraw(s, i, locale, seed, verifiable, deferred, draw)
+}
+
+// prepareBasic prepares the integer, string, uuid and dice subcommands
+func prepareBasic(locale i18n.SupportedLocale, subcommand *discordgo.ApplicationCommandInteractionDataOption) drawFunc {
+	return func(r *rand.Rand) (*discordgo.InteractionResponseData, error) {
+		var result string
+
+		switch subcommand.Name {
+		case "integer":
+			min := int64(0)
+			max := int64(100)
+			for _, opt := range subcommand.Options {
+				if opt.Name == "min" {
+					min = opt.IntValue()
+				} else if opt.Name == "max" {
+					max = opt.IntValue()
+				}
 			}
+			if min > max {
+				min, max = max, min
+			}
+			val := r.Int63n(max-min+1) + min
+			result = fmt.Sprintf("%d (%s)", val, i18n.Tf(locale, "command.random.range.integer", min, max))
+		case "string":
+			length := 8
+			for _, opt := range subcommand.Options {
+				if opt.Name == "length" {
+					length = int(opt.IntValue())
+				}
+			}
+			b := make([]byte, length)
+			for i := range b {
+				b[i] = charset[r.Intn(len(charset))]
+			}
+			result = fmt.Sprintf("%s (%s)", string(b), i18n.Tf(locale, "command.random.range.string", length))
+		case "uuid":
+			// Read from the seeded source so the UUID is reproducible too
+			id, err := uuid.NewRandomFromReader(r)
+			if err != nil {
+				return nil, err
+			}
+			result = id.String()
+		case "dice":
+			face := int64(6)
+			for _, opt := range subcommand.Options {
+				if opt.Name == "face" {
+					face = opt.IntValue()
+				}
+			}
+			if face < 2 {
+				face = 2
+			}
+			val := r.Int63n(face) + 1
+			result = fmt.Sprintf("%d (%s)", val, i18n.Tf(locale, "command.random.range.dice", face))
 		}
-		if face < 2 {
-			face = 2
-		}
-		val := r.Int63n(face) + 1
-		result = fmt.Sprintf("%d (%s)", val, i18n.Tf(locale, "command.random.range.dice", face))
-	}
 
-	return interactions.RespondCustom(s, i, &discordgo.InteractionResponseData{
-		Content: i18n.Tf(locale, "command.random.result", result),
-	})
+		return &discordgo.InteractionResponseData{
+			Content: i18n.Tf(locale, "command.random.result", result),
+		}, nil
+	}
 }
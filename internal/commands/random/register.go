@@ -0,0 +1,15 @@
+package random
+
+import (
+	"hiei-discord-bot/internal/interactions"
+)
+
+func init() {
+	router := interactions.GetRouter()
+
+	// Register modal handler for pick/shuffle/weighted list input
+	router.RegisterModal(choiceModalPrefix, HandleChoiceModalSubmit)
+
+	// Register re-roll button handler for teams/member draws
+	router.RegisterComponent(rerollButtonPrefix, HandleReroll)
+}
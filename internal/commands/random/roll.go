@@ -0,0 +1,57 @@
+package random
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+
+	"hiei-discord-bot/internal/commands/random/dice"
+	"hiei-discord-bot/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// maxBreakdownLength keeps the reply under Discord's 2000 character message limit
+	maxBreakdownLength = 1500
+)
+
+// prepareRoll parses a dice expression; the draw evaluates it and shows the total and per-die breakdown
+func prepareRoll(locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) (drawFunc, error) {
+	expression := ""
+	for _, opt := range options {
+		if opt.Name == "expression" {
+			expression = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	node, err := dice.Parse(expression)
+	if err != nil {
+		return nil, diceUserError(err)
+	}
+
+	return func(r *rand.Rand) (*discordgo.InteractionResponseData, error) {
+		result, err := dice.Evaluate(node, r)
+		if err != nil {
+			return nil, diceUserError(err)
+		}
+
+		breakdown := result.Breakdown
+		if len([]rune(breakdown)) > maxBreakdownLength {
+			breakdown = string([]rune(breakdown)[:maxBreakdownLength]) + "…"
+		}
+
+		return &discordgo.InteractionResponseData{
+			Content: i18n.Tf(locale, "command.random.roll.result", expression, result.Total, breakdown),
+		}, nil
+	}, nil
+}
+
+// diceUserError converts a dice error into its localized user error
+func diceUserError(err error) error {
+	var diceErr *dice.Error
+	if errors.As(err, &diceErr) {
+		return newUserError("command.random.roll.error."+diceErr.Code, diceErr.Args...)
+	}
+	return err
+}
@@ -0,0 +1,310 @@
+package random
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strings"
+
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	maxTeams           = 10
+	maxPoolSize        = 100 // Keeps the mention list within the embed description limit
+	maxMemberPages     = 5   // Pages of 1000 members fetched when drawing from a role
+	rerollButtonPrefix = "random_reroll_"
+)
+
+var mentionPattern = regexp.MustCompile(`<@!?(\d+)>`)
+
+// prepareMemberDraw collects and validates the member pool for the teams and member subcommands
+func prepareMemberDraw(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, mode string, options []*discordgo.ApplicationCommandInteractionDataOption) (drawFunc, error) {
+	if i.GuildID == "" {
+		return nil, newUserError("command.random.teams.error.guild_only")
+	}
+
+	count := 1
+	if mode == "teams" {
+		count = 2
+	}
+	var roleID, channelID, mentions string
+	for _, opt := range options {
+		switch opt.Name {
+		case "count":
+			count = int(opt.IntValue())
+		case "role":
+			roleID = opt.RoleValue(nil, "").ID
+		case "voice":
+			channelID = opt.ChannelValue(nil).ID
+		case "members":
+			mentions = opt.StringValue()
+		}
+	}
+
+	pool, err := collectPool(s, i, roleID, channelID, mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(pool) == 0:
+		return nil, newUserError("command.random.teams.error.empty_pool")
+	case len(pool) > maxPoolSize:
+		return nil, newUserError("command.random.teams.error.pool_too_large", maxPoolSize)
+	case mode == "teams" && (count < 2 || count > len(pool)):
+		return nil, newUserError("command.random.teams.error.invalid_team_count", len(pool))
+	case mode == "member" && (count < 1 || count > len(pool)):
+		return nil, newUserError("command.random.teams.error.invalid_winner_count", len(pool))
+	}
+
+	// Sources list members in no fixed order; sorting makes seeded draws reproducible
+	sort.Strings(pool)
+
+	draw := &Draw{
+		OwnerID: interactions.UserID(i),
+		Mode:    mode,
+		Count:   count,
+		Pool:    pool,
+		Locale:  locale,
+	}
+	GetDrawStore().Save(draw)
+
+	return func(r *rand.Rand) (*discordgo.InteractionResponseData, error) {
+		return buildDrawMessage(draw, r), nil
+	}, nil
+}
+
+// HandleReroll re-rolls a stored draw with a new seed; only the user who started it may do so
+func HandleReroll(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+	drawID := strings.TrimPrefix(i.MessageComponentData().CustomID, rerollButtonPrefix)
+
+	draw, exists := GetDrawStore().Get(drawID)
+	if !exists {
+		return interactions.RespondError(s, i, locale, "command.random.teams.error.expired", true)
+	}
+	if draw.OwnerID != interactions.UserID(i) {
+		return interactions.RespondError(s, i, locale, "command.random.teams.error.not_owner", true)
+	}
+
+	seed := newSeed()
+	data := buildDrawMessage(draw, newRand(seed))
+	data.Content = i18n.Tf(draw.Locale, "command.random.seed", seed)
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: data,
+	})
+}
+
+// collectPool gathers the eligible non-bot user IDs from the chosen source.
+// Without a source the invoker's current voice channel is used.
+func collectPool(s *discordgo.Session, i *discordgo.InteractionCreate, roleID, channelID, mentions string) ([]string, error) {
+	sources := 0
+	for _, value := range []string{roleID, channelID, mentions} {
+		if value != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return nil, newUserError("command.random.teams.error.multiple_sources")
+	}
+
+	switch {
+	case roleID != "":
+		return roleMembers(s, i.GuildID, roleID)
+	case mentions != "":
+		return mentionedMembers(s, i, mentions)
+	case channelID != "":
+		return voiceMembers(s, i, channelID)
+	}
+
+	voiceState, err := s.State.VoiceState(i.GuildID, interactions.UserID(i))
+	if err != nil || voiceState.ChannelID == "" {
+		return nil, newUserError("command.random.teams.error.no_source")
+	}
+	return voiceMembers(s, i, voiceState.ChannelID)
+}
+
+// roleMembers lists the members holding a role.
+// Listing members requires the Server Members privileged intent to be enabled for the application.
+func roleMembers(s *discordgo.Session, guildID, roleID string) ([]string, error) {
+	var pool []string
+	after := ""
+
+	for page := 0; page < maxMemberPages; page++ {
+		members, err := s.GuildMembers(guildID, after, 1000)
+		if err != nil {
+			return nil, newUserError("command.random.teams.error.members_unavailable")
+		}
+
+		for _, member := range members {
+			if member.User == nil || member.User.Bot {
+				continue
+			}
+			// @everyone shares the guild ID and is implied for every member
+			if roleID == guildID || hasRole(member, roleID) {
+				pool = append(pool, member.User.ID)
+			}
+		}
+
+		if len(members) < 1000 {
+			break
+		}
+		after = members[len(members)-1].User.ID
+	}
+
+	return pool, nil
+}
+
+// voiceMembers lists the users connected to a voice channel
+func voiceMembers(s *discordgo.Session, i *discordgo.InteractionCreate, channelID string) ([]string, error) {
+	guild, err := s.State.Guild(i.GuildID)
+	if err != nil {
+		return nil, newUserError("command.random.teams.error.members_unavailable")
+	}
+
+	s.State.RLock()
+	var userIDs []string
+	for _, voiceState := range guild.VoiceStates {
+		if voiceState.ChannelID == channelID {
+			userIDs = append(userIDs, voiceState.UserID)
+		}
+	}
+	s.State.RUnlock()
+
+	var pool []string
+	for _, userID := range userIDs {
+		if !isBot(s, i, userID) {
+			pool = append(pool, userID)
+		}
+	}
+	return pool, nil
+}
+
+// mentionedMembers lists the distinct users mentioned in the text
+func mentionedMembers(s *discordgo.Session, i *discordgo.InteractionCreate, text string) ([]string, error) {
+	seen := make(map[string]bool)
+	var pool []string
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		userID := match[1]
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		if len(seen) > maxPoolSize {
+			return nil, newUserError("command.random.teams.error.pool_too_large", maxPoolSize)
+		}
+		if !isBot(s, i, userID) {
+			pool = append(pool, userID)
+		}
+	}
+	return pool, nil
+}
+
+// isBot reports whether a user is known to be a bot, from the users resolved
+// with the interaction or the state cache. Users missing from both are kept
+// rather than fetched one request at a time.
+func isBot(s *discordgo.Session, i *discordgo.InteractionCreate, userID string) bool {
+	if resolved := i.ApplicationCommandData().Resolved; resolved != nil {
+		if user, ok := resolved.Users[userID]; ok {
+			return user.Bot
+		}
+	}
+	if member, err := s.State.Member(i.GuildID, userID); err == nil && member.User != nil {
+		return member.User.Bot
+	}
+	return false
+}
+
+func hasRole(member *discordgo.Member, roleID string) bool {
+	for _, id := range member.Roles {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// shufflePool returns a shuffled copy of the pool
+func shufflePool(pool []string, r *rand.Rand) []string {
+	shuffled := make([]string, len(pool))
+	copy(shuffled, pool)
+	r.Shuffle(len(shuffled), func(a, b int) {
+		shuffled[a], shuffled[b] = shuffled[b], shuffled[a]
+	})
+	return shuffled
+}
+
+// splitTeams shuffles the pool and deals it round-robin, so team sizes differ by at most one
+func splitTeams(pool []string, count int, r *rand.Rand) [][]string {
+	teams := make([][]string, count)
+	for idx, userID := range shufflePool(pool, r) {
+		teams[idx%count] = append(teams[idx%count], userID)
+	}
+	return teams
+}
+
+// buildDrawMessage draws a fresh result for the draw and renders it with the re-roll button
+func buildDrawMessage(draw *Draw, r *rand.Rand) *discordgo.InteractionResponseData {
+	locale := draw.Locale
+	var builder strings.Builder
+	var title string
+
+	if draw.Mode == "teams" {
+		title = i18n.Tf(locale, "command.random.teams.title", draw.Count, len(draw.Pool))
+		for idx, team := range splitTeams(draw.Pool, draw.Count, r) {
+			builder.WriteString(i18n.Tf(locale, "command.random.teams.team", idx+1, len(team)) + "\n")
+			builder.WriteString(mentionList(team) + "\n\n")
+		}
+	} else {
+		title = i18n.Tf(locale, "command.random.member.title", draw.Count, len(draw.Pool))
+		builder.WriteString(mentionList(shufflePool(draw.Pool, r)[:draw.Count]))
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       title,
+				Description: builder.String(),
+				Color:       0x3498DB,
+				Footer: &discordgo.MessageEmbedFooter{
+					Text: i18n.T(locale, "command.random.teams.footer"),
+				},
+			},
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(locale, "command.random.teams.button.reroll"),
+						Style:    discordgo.SecondaryButton,
+						CustomID: rerollButtonPrefix + draw.ID,
+						Emoji: &discordgo.ComponentEmoji{
+							Name: "🔄",
+						},
+					},
+				},
+			},
+		},
+		// Mentions are for display only
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{},
+		},
+	}
+}
+
+func mentionList(userIDs []string) string {
+	mentions := make([]string, len(userIDs))
+	for idx, userID := range userIDs {
+		mentions[idx] = fmt.Sprintf("<@%s>", userID)
+	}
+	return strings.Join(mentions, ", ")
+}
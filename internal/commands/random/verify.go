@@ -0,0 +1,70 @@
+package random
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// newServerSeed generates the secret server seed of a verifiable draw
+func newServerSeed() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand never fails on supported platforms
+	}
+	return hex.EncodeToString(b)
+}
+
+// commit returns the published commitment: hex(SHA-256(serverSeed))
+func commit(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// deriveSeed returns the draw seed: hex(HMAC-SHA256(key=serverSeed, message=clientSeed))
+func deriveSeed(serverSeed, clientSeed string) string {
+	mac := hmac.New(sha256.New, []byte(serverSeed))
+	mac.Write([]byte(clientSeed))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// handleVerify checks a revealed server seed against its commitment and
+// returns the draw seed that reproduces the result
+func handleVerify(s *discordgo.Session, i *discordgo.InteractionCreate, locale i18n.SupportedLocale, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	var serverSeed, clientSeed, commitment string
+	for _, opt := range options {
+		switch opt.Name {
+		case "server_seed":
+			serverSeed = strings.TrimSpace(opt.StringValue())
+		case "client_seed":
+			clientSeed = strings.TrimSpace(opt.StringValue())
+		case "commitment":
+			commitment = strings.ToLower(strings.TrimSpace(opt.StringValue()))
+		}
+	}
+
+	computed := commit(serverSeed)
+	drawSeed := deriveSeed(serverSeed, clientSeed)
+
+	var status string
+	switch {
+	case commitment == "":
+		status = i18n.Tf(locale, "command.random.verify.commitment_computed", computed)
+	case hmac.Equal([]byte(commitment), []byte(computed)):
+		status = i18n.T(locale, "command.random.verify.match")
+	default:
+		status = i18n.Tf(locale, "command.random.verify.mismatch", computed)
+	}
+
+	return interactions.RespondCustom(s, i, &discordgo.InteractionResponseData{
+		Content: status + "\n" + i18n.Tf(locale, "command.random.verify.result", drawSeed, drawSeed),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"log/slog"
+	"sort"
 	"strings"
 	"sync"
 
@@ -14,7 +15,7 @@ import (
 
 // Registry manages all bot commands
 type Registry struct {
-	commands map[string]Command
+	commands map[string]Command // Key(definition) -> command
 	mu       sync.RWMutex
 }
 
@@ -39,58 +40,104 @@ func Register(cmd Command) {
 	globalRegistry.Register(cmd)
 }
 
+// Key returns the key identifying a command definition in the registry and the
+// version tables. Slash commands are keyed by their lowercase name; context menu
+// commands are prefixed with their type, so the "Blame" message command does not
+// replace the /blame slash command.
+func Key(def *discordgo.ApplicationCommand) string {
+	return commandKey(def.Type, def.Name)
+}
+
+func commandKey(commandType discordgo.ApplicationCommandType, name string) string {
+	name = strings.ToLower(name)
+	switch commandType {
+	case discordgo.UserApplicationCommand:
+		return "user:" + name
+	case discordgo.MessageApplicationCommand:
+		return "message:" + name
+	default:
+		return name
+	}
+}
+
 // Register adds a command to the registry
 func (r *Registry) Register(cmd Command) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	name := strings.ToLower(cmd.Definition().Name)
+	name := Key(cmd.Definition())
 	r.commands[name] = cmd
-	slog.Info("Registered command", "name", name)
+	slog.Debug("Registered command", "name", name)
 
 	// Auto-register settings if command is configurable
 	if conf, ok := cmd.(settings.Configurable); ok {
 		mgr := settings.GetManager()
 		for _, def := range conf.Settings() {
 			mgr.Register(def)
-			slog.Info("Registered setting", "key", def.Key, "command", name)
+			slog.Debug("Registered setting", "key", def.Key, "command", name)
 		}
 	}
 }
 
-// Get retrieves a command by name
+// Get retrieves a slash command by name
 func (r *Registry) Get(name string) (Command, bool) {
+	return r.GetByType(discordgo.ChatApplicationCommand, name)
+}
+
+// GetByType retrieves a command by type and name
+func (r *Registry) GetByType(commandType discordgo.ApplicationCommandType, name string) (Command, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cmd, exists := r.commands[commandKey(commandType, name)]
+	return cmd, exists
+}
+
+// GetByKey retrieves a command by its registry key (see Key)
+func (r *Registry) GetByKey(key string) (Command, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	cmd, exists := r.commands[strings.ToLower(name)]
+	cmd, exists := r.commands[key]
 	return cmd, exists
 }
 
-// All returns all registered commands
+// All returns all registered commands sorted by key
 func (r *Registry) All() []Command {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	cmds := make([]Command, 0, len(r.commands))
-	for _, cmd := range r.commands {
-		cmds = append(cmds, cmd)
+	keys := r.sortedKeys()
+	cmds := make([]Command, 0, len(keys))
+	for _, key := range keys {
+		cmds = append(cmds, r.commands[key])
 	}
 	return cmds
 }
 
-// GetDefinitions returns all command definitions for Discord registration
+// GetDefinitions returns all command definitions for Discord registration, sorted by key
 func (r *Registry) GetDefinitions() []*discordgo.ApplicationCommand {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	definitions := make([]*discordgo.ApplicationCommand, 0, len(r.commands))
-	for _, cmd := range r.commands {
-		definitions = append(definitions, cmd.Definition())
+	keys := r.sortedKeys()
+	definitions := make([]*discordgo.ApplicationCommand, 0, len(keys))
+	for _, key := range keys {
+		definitions = append(definitions, r.commands[key].Definition())
 	}
 	return definitions
 }
 
+// sortedKeys returns the registry keys in order; the caller must hold the lock
+func (r *Registry) sortedKeys() []string {
+	keys := make([]string, 0, len(r.commands))
+	for key := range r.commands {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // HandleInteraction processes incoming slash command interactions
 func (r *Registry) HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	// Only handle application commands (slash commands)
@@ -99,10 +146,10 @@ func (r *Registry) HandleInteraction(s *discordgo.Session, i *discordgo.Interact
 	}
 
 	data := i.ApplicationCommandData()
-	cmdName := strings.ToLower(data.Name)
+	cmdName := commandKey(data.CommandType, data.Name)
 
 	// Get and execute command
-	cmd, exists := r.Get(cmdName)
+	cmd, exists := r.GetByType(data.CommandType, data.Name)
 	if !exists {
 		slog.Warn("Unknown command received", "command", cmdName)
 		return
@@ -1,13 +1,20 @@
 package reload
 
 import (
+	"errors"
 	"fmt"
 	"hiei-discord-bot/internal/commands"
+	"hiei-discord-bot/internal/config"
 	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
 )
 
+// maxChangeFields leaves one of Discord's 25 embed fields for the overflow note
+const maxChangeFields = 24
+
 func init() {
 	commands.Register(New())
 }
@@ -22,15 +29,40 @@ func New() *Command {
 
 // Definition returns the slash command definition
 func (c *Command) Definition() *discordgo.ApplicationCommand {
+	administrator := int64(discordgo.PermissionAdministrator)
+
 	return &discordgo.ApplicationCommand{
-		Name:        "reload",
-		Description: "Reload all slash commands (admin only)",
+		Name:                     "reload",
+		Description:              "Reload the slash commands of this server (admin only)",
+		DefaultMemberPermissions: &administrator,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "command",
+				Description: "Only sync this command, e.g. help or user:blame",
+				Required:    false,
+				MaxLength:   100,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "dry_run",
+				Description: "Preview the changes without applying them",
+				Required:    false,
+			},
+		},
 	}
 }
 
 // Version returns the command version
 func (c *Command) Version() string {
-	return "1.0.0"
+	return "1.2.0"
+}
+
+// Describe returns the help metadata
+func (c *Command) Describe() commands.Metadata {
+	return commands.Metadata{
+		Category: commands.CategoryAdmin,
+	}
 }
 
 // Execute runs the reload command
@@ -44,6 +76,33 @@ func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate)
 		return fmt.Errorf("%s", i18n.T(locale, "command.reload.guild_only"))
 	}
 
+	// Server administrators can grant the command to other roles, so the
+	// permission is checked here as well
+	if i.Member == nil || i.Member.Permissions&discordgo.PermissionAdministrator == 0 {
+		return interactions.RespondError(s, i, locale, "command.reload.no_permission", true)
+	}
+
+	// In global mode the global commands are reloaded instead of this guild's.
+	// They are shared by every server, so only the bot owners may reload them.
+	scope := guildID
+	if config.Get().CommandRegistration == config.RegistrationGlobal {
+		if !config.Get().IsOwner(interactions.UserID(i)) {
+			return interactions.RespondError(s, i, locale, "command.reload.owner_only", true)
+		}
+		scope = commands.GlobalScope
+	}
+
+	command := ""
+	dryRun := false
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "command":
+			command = normalizeKey(opt.StringValue())
+		case "dry_run":
+			dryRun = opt.BoolValue()
+		}
+	}
+
 	// Respond immediately to acknowledge the interaction
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
@@ -55,29 +114,114 @@ func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate)
 		return fmt.Errorf("failed to defer response: %w", err)
 	}
 
-	// Perform the reload for this guild
-	definitions := commands.Global().GetDefinitions()
-	reloadErr := commands.SyncGuildCommands(s, commands.Global(), guildID, true)
+	plan, reloadErr := commands.PlanGuildSync(s, commands.Global(), scope, command)
+	if reloadErr == nil && !dryRun {
+		reloadErr = commands.ApplyGuildSync(s, plan)
+	}
 	if reloadErr != nil {
 		// Send error message as follow-up
-		errorMsg := fmt.Sprintf("%s %s", i18n.T(locale, "common.error_prefix"), i18n.Tf(locale, "command.reload.failed", reloadErr))
+		message := i18n.Tf(locale, "command.reload.failed", reloadErr)
+		if errors.Is(reloadErr, commands.ErrUnknownCommand) {
+			message = i18n.Tf(locale, "command.reload.unknown_command", command)
+		}
+		errorMsg := fmt.Sprintf("%s %s", i18n.T(locale, "common.error_prefix"), message)
 		_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 			Content: strPtr(errorMsg),
 		})
+		if errors.Is(reloadErr, commands.ErrUnknownCommand) {
+			return err
+		}
 		return reloadErr
 	}
 
-	cmdCount := len(definitions)
-
-	// Send success message as follow-up
-	successMsg := fmt.Sprintf("%s %s", i18n.T(locale, "common.success_prefix"), i18n.Tf(locale, "command.reload.success", cmdCount))
 	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-		Content: strPtr(successMsg),
+		Embeds: &[]*discordgo.MessageEmbed{planEmbed(locale, plan, dryRun)},
 	})
 
 	return err
 }
 
+// planEmbed reports the changes of a sync plan, previewed or applied
+func planEmbed(locale i18n.SupportedLocale, plan *commands.SyncPlan, dryRun bool) *discordgo.MessageEmbed {
+	counts := make(map[commands.ChangeKind]int)
+	for _, change := range plan.Changes {
+		counts[change.Kind]++
+	}
+
+	scope := i18n.T(locale, "command.reload.scope.guild")
+	if plan.GuildID == commands.GlobalScope {
+		scope = i18n.T(locale, "command.reload.scope.global")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  i18n.T(locale, "command.reload.title.applied"),
+		Footer: &discordgo.MessageEmbedFooter{Text: scope},
+		Color:  0x2ECC71,
+		Description: i18n.Tf(locale, "command.reload.summary",
+			counts[commands.ChangeCreate], counts[commands.ChangeUpdate], counts[commands.ChangeDelete], len(plan.Unchanged)),
+	}
+	if dryRun {
+		embed.Title = i18n.T(locale, "command.reload.title.preview")
+		embed.Color = 0x5865F2
+	}
+	if len(plan.Changes) == 0 {
+		embed.Description += "\n" + i18n.T(locale, "command.reload.up_to_date")
+		return embed
+	}
+
+	for n, change := range plan.Changes {
+		if n == maxChangeFields {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  "…",
+				Value: i18n.Tf(locale, "command.reload.more", len(plan.Changes)-n),
+			})
+			break
+		}
+		embed.Fields = append(embed.Fields, changeField(locale, change))
+	}
+	return embed
+}
+
+// changeField describes a single change, listing the fields an update touches
+func changeField(locale i18n.SupportedLocale, change commands.Change) *discordgo.MessageEmbedField {
+	icon := map[commands.ChangeKind]string{
+		commands.ChangeCreate: "➕",
+		commands.ChangeUpdate: "✏️",
+		commands.ChangeDelete: "🗑️",
+	}[change.Kind]
+
+	value := i18n.T(locale, "command.reload.change."+string(change.Kind))
+	if len(change.Fields) > 0 {
+		value = "`" + strings.Join(change.Fields, "`\n`") + "`"
+		// Field values hold at most 1024 characters; cut at a line when there is one
+		if runes := []rune(value); len(runes) > 1024 {
+			kept := string(runes[:1020])
+			if cut := strings.LastIndex(kept, "\n"); cut >= 0 {
+				kept = kept[:cut]
+			}
+			value = kept + "\n…"
+		}
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  fmt.Sprintf("%s %s", icon, displayKey(change.Key)),
+		Value: value,
+	}
+}
+
+// normalizeKey turns user input such as "/Help" into a registry key
+func normalizeKey(input string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(input), "/"))
+}
+
+// displayKey shows slash commands with their leading slash
+func displayKey(key string) string {
+	if strings.Contains(key, ":") {
+		return key
+	}
+	return "/" + key
+}
+
 // strPtr is a helper function to get a string pointer
 func strPtr(s string) *string {
 	return &s
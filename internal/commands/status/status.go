@@ -0,0 +1,198 @@
+package status
+
+import (
+	"fmt"
+	"hiei-discord-bot/internal/buildinfo"
+	"hiei-discord-bot/internal/commands"
+	"hiei-discord-bot/internal/commands/game"
+	"hiei-discord-bot/internal/config"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/interactions"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// startedAt approximates the process start, as packages are initialized on startup
+var startedAt = time.Now()
+
+func init() {
+	commands.Register(New())
+}
+
+// Command implements the status slash command
+type Command struct{}
+
+// New creates a new status command instance
+func New() *Command {
+	return &Command{}
+}
+
+// Definition returns the slash command definition
+func (c *Command) Definition() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "status",
+		Description: "Show the bot's latency, uptime and build",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "detailed",
+				Description: "Include runtime, game and database statistics (bot owners only)",
+				Required:    false,
+			},
+		},
+	}
+}
+
+// Version returns the command version
+func (c *Command) Version() string {
+	return "1.0.0"
+}
+
+// Describe returns the help metadata
+func (c *Command) Describe() commands.Metadata {
+	return commands.Metadata{Category: commands.CategoryGeneral}
+}
+
+// Execute runs the status command
+func (c *Command) Execute(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.GetUserLocaleFromInteraction(i)
+
+	detailed := false
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "detailed" {
+			detailed = opt.BoolValue()
+		}
+	}
+	if detailed && !config.Get().IsOwner(interactions.UserID(i)) {
+		return interactions.RespondError(s, i, locale, "command.status.error.owner_only", true)
+	}
+
+	// The deferred response doubles as the REST round-trip measurement
+	start := time.Now()
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	restLatency := time.Since(start)
+
+	build := buildinfo.Get()
+	commit := build.ShortCommit()
+	if build.Modified {
+		commit += "-dirty"
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{Name: i18n.T(locale, "command.status.field.gateway"), Value: formatLatency(s.HeartbeatLatency()), Inline: true},
+		{Name: i18n.T(locale, "command.status.field.rest"), Value: formatLatency(restLatency), Inline: true},
+		{Name: i18n.T(locale, "command.status.field.uptime"), Value: formatUptime(locale, time.Since(startedAt)), Inline: true},
+		{Name: i18n.T(locale, "command.status.field.build"), Value: "`" + commit + "`", Inline: true},
+	}
+	if detailed {
+		fields = append(fields, detailedFields(s, locale, build)...)
+	}
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{
+			{
+				Title:     i18n.T(locale, "command.status.title"),
+				Color:     0x2ECC71,
+				Fields:    fields,
+				Timestamp: time.Now().Format(time.RFC3339),
+			},
+		},
+	})
+	return err
+}
+
+// detailedFields reports runtime, guild, game session and database statistics
+func detailedFields(s *discordgo.Session, locale i18n.SupportedLocale, build buildinfo.Info) []*discordgo.MessageEmbedField {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	guilds := 0
+	if s.State != nil {
+		s.State.RLock()
+		guilds = len(s.State.Guilds)
+		s.State.RUnlock()
+	}
+
+	database := i18n.T(locale, "command.status.unavailable")
+	if size, err := databaseSize(config.Get().DatabasePath); err == nil {
+		database = formatBytes(size)
+	}
+
+	return []*discordgo.MessageEmbedField{
+		{Name: i18n.T(locale, "command.status.field.go"), Value: build.GoVersion, Inline: true},
+		{Name: i18n.T(locale, "command.status.field.goroutines"), Value: fmt.Sprint(runtime.NumGoroutine()), Inline: true},
+		{Name: i18n.T(locale, "command.status.field.heap"), Value: fmt.Sprintf("%s / %s", formatBytes(int64(mem.HeapAlloc)), formatBytes(int64(mem.HeapSys))), Inline: true},
+		{Name: i18n.T(locale, "command.status.field.guilds"), Value: fmt.Sprint(guilds), Inline: true},
+		{Name: i18n.T(locale, "command.status.field.database"), Value: database, Inline: true},
+		{Name: i18n.T(locale, "command.status.field.sessions"), Value: formatSessions(locale, game.ActiveSessions())},
+	}
+}
+
+// databaseSize adds up the SQLite database and its write-ahead log
+func databaseSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+
+	size := info.Size()
+	if wal, err := os.Stat(path + "-wal"); err == nil {
+		size += wal.Size()
+	}
+	return size, nil
+}
+
+// formatSessions lists the games with sessions in progress
+func formatSessions(locale i18n.SupportedLocale, sessions map[string]int) string {
+	names := make([]string, 0, len(sessions))
+	for name, count := range sessions {
+		if count > 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return i18n.T(locale, "command.status.no_sessions")
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("`%s` %d", name, sessions[name]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatLatency(latency time.Duration) string {
+	return fmt.Sprintf("%d ms", latency.Milliseconds())
+}
+
+func formatUptime(locale i18n.SupportedLocale, uptime time.Duration) string {
+	total := int(uptime.Seconds())
+	return i18n.Tf(locale, "command.status.uptime", total/86400, total%86400/3600, total%3600/60, total%60)
+}
+
+func formatBytes(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GiB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
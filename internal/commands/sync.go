@@ -11,7 +11,9 @@ import (
 	"golang.org/x/mod/semver"
 )
 
-// SyncLocalCommandVersions checks and updates local command versions in the database
+// SyncLocalCommandVersions checks and updates local command versions in the database.
+// A command's build time moves forward when its version is bumped or its
+// definition hash changes, which makes SyncGuildCommands re-sync it.
 func SyncLocalCommandVersions(registry *Registry) error {
 	mgr := settings.GetManager()
 	allCommands := registry.All()
@@ -19,25 +21,39 @@ func SyncLocalCommandVersions(registry *Registry) error {
 
 	for _, cmd := range allCommands {
 		def := cmd.Definition()
+		name := Key(def)
 		version := fmt.Sprintf("v%s", cmd.Version())
-		currentCommandNames[def.Name] = true
+		hash := Hash(def)
+		currentCommandNames[name] = true
 
-		dbVer, err := mgr.GetLocalCommandVersionAndBuildTime(def.Name)
+		dbVer, err := mgr.GetLocalCommandVersionAndBuildTime(name)
 		if err != nil {
-			slog.Error("Failed to get local command version", "name", def.Name, "error", err)
+			slog.Error("Failed to get local command version", "name", name, "error", err)
 			continue
 		}
 
-		// If version is newer or not exists, update build_time
-		if dbVer.Version == "" || isVersionNewer(version, dbVer.Version) {
-			slog.Info("Updating local command version", "name", def.Name, "old", dbVer.Version, "new", version)
-			err := mgr.UpdateLocalCommandVersionAndBuildTime(def.Name, models.CommandVersion{
-				Version:   version,
-				BuildTime: time.Now().UTC(),
-			})
-			if err != nil {
-				slog.Error("Failed to update local command version", "name", def.Name, "error", err)
-			}
+		buildTime := time.Now().UTC()
+		switch {
+		case dbVer.Version == "" || isVersionNewer(version, dbVer.Version):
+			// If version is newer or not exists, update build_time
+			slog.Info("Updating local command version", "name", name, "old", dbVer.Version, "new", version)
+		case dbVer.Hash == "":
+			// Rows from before hashing: record the hash without forcing a re-sync
+			buildTime = dbVer.BuildTime
+		case dbVer.Hash != hash:
+			// The definition changed, so guilds need it even without a version bump
+			slog.Warn("Command definition changed without a version bump", "name", name, "version", version)
+		default:
+			continue
+		}
+
+		err = mgr.UpdateLocalCommandVersionAndBuildTime(name, models.CommandVersion{
+			Version:   version,
+			Hash:      hash,
+			BuildTime: buildTime,
+		})
+		if err != nil {
+			slog.Error("Failed to update local command version", "name", name, "error", err)
 		}
 	}
 
@@ -59,13 +75,48 @@ func SyncLocalCommandVersions(registry *Registry) error {
 	return nil
 }
 
-// SyncGuildCommands synchronizes commands for a specific guild
+// GlobalScope is the guild ID of global commands, both for the Discord API and
+// the guild version table
+const GlobalScope = ""
+
+// SyncGlobalCommands synchronizes the commands registered for all guilds
+func SyncGlobalCommands(session *discordgo.Session, registry *Registry, force bool) error {
+	return SyncGuildCommands(session, registry, GlobalScope, force)
+}
+
+// RemoveGuildCommands removes the commands a previous registration mode left in
+// a guild, or globally for GlobalScope. Nothing is requested from Discord unless
+// the version table still tracks commands there.
+func RemoveGuildCommands(session *discordgo.Session, guildID string) error {
+	mgr := settings.GetManager()
+	names, err := mgr.GetGuildCommandNames(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to get tracked commands: %w", err)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	slog.Info("Removing commands of previous registration mode", "guild_id", guildID, "count", len(names))
+	if _, err := session.ApplicationCommandBulkOverwrite(session.State.User.ID, guildID, []*discordgo.ApplicationCommand{}); err != nil {
+		return fmt.Errorf("failed to remove commands: %w", err)
+	}
+
+	for _, name := range names {
+		if err := mgr.DeleteGuildCommandVersion(guildID, name); err != nil {
+			slog.Warn("Failed to delete guild command version from DB", "guild_id", guildID, "name", name, "error", err)
+		}
+	}
+	return nil
+}
+
+// SyncGuildCommands synchronizes commands for a specific guild. When any command
+// is outdated, missing or obsolete, the whole set is replaced with one bulk overwrite.
 func SyncGuildCommands(session *discordgo.Session, registry *Registry, guildID string, force bool) error {
 	slog.Info("Starting command sync", "guild_id", guildID, "force", force)
 
-	// Get all commands from registry
-	allCommands := registry.All()
-	slog.Debug("Commands to sync", "count", len(allCommands))
+	definitions := registry.GetDefinitions()
+	slog.Debug("Commands to sync", "count", len(definitions))
 
 	mgr := settings.GetManager()
 
@@ -77,107 +128,65 @@ func SyncGuildCommands(session *discordgo.Session, registry *Registry, guildID s
 	}
 	slog.Info("Existing commands fetched", "count", len(existingCmds), "guild_id", guildID)
 
-	if force {
-		// Delete all existing commands
-		for _, cmd := range existingCmds {
-			slog.Debug("Deleting command (force)", "name", cmd.Name, "id", cmd.ID)
-			err := session.ApplicationCommandDelete(session.State.User.ID, guildID, cmd.ID)
-			if err != nil {
-				slog.Warn("Failed to delete command", "command", cmd.Name, "id", cmd.ID, "error", err)
-			}
-		}
-		existingCmds = nil
-	}
-
+	// Key by type and name: a slash command and a context menu command may share a name
 	existingMap := make(map[string]*discordgo.ApplicationCommand)
 	for _, cmd := range existingCmds {
-		existingMap[cmd.Name] = cmd
-	}
-
-	defMap := make(map[string]*discordgo.ApplicationCommand)
-	for _, cmd := range allCommands {
-		defMap[cmd.Definition().Name] = cmd.Definition()
-	}
-
-	// Delete commands that are no longer in registry
-	for name, cmd := range existingMap {
-		if _, exists := defMap[name]; !exists {
-			slog.Info("Deleting obsolete command", "name", name, "id", cmd.ID, "guild_id", guildID)
-			err := session.ApplicationCommandDelete(session.State.User.ID, guildID, cmd.ID)
-			if err != nil {
-				slog.Warn("Failed to delete obsolete command", "name", name, "error", err)
-			}
-
-			// Also delete from guild_command_versions DB
-			if err := mgr.DeleteGuildCommandVersion(guildID, name); err != nil {
-				slog.Warn("Failed to delete obsolete guild command version from DB", "guild_id", guildID, "name", name, "error", err)
-			}
-		}
+		existingMap[Key(cmd)] = cmd
 	}
 
-	// Register or update commands
-	var failedCommands []string
-	successCount := 0
-	skipCount := 0
+	var outdated []string
+	for _, def := range definitions {
+		name := Key(def)
+		delete(existingMap, name)
 
-	for _, cmd := range allCommands {
-		def := cmd.Definition()
-		localVer, err := mgr.GetLocalCommandVersionAndBuildTime(def.Name)
+		localVer, err := mgr.GetLocalCommandVersionAndBuildTime(name)
 		if err != nil {
-			slog.Error("Failed to get local command version", "name", def.Name, "error", err)
+			slog.Error("Failed to get local command version", "name", name, "error", err)
 			continue
 		}
 
-		guildVerTime, err := mgr.GetGuildCommandLastVersionTime(guildID, def.Name)
+		guildVerTime, err := mgr.GetGuildCommandLastVersionTime(guildID, name)
 		if err != nil {
-			slog.Error("Failed to get guild command version time", "guild_id", guildID, "name", def.Name, "error", err)
+			slog.Error("Failed to get guild command version time", "guild_id", guildID, "name", name, "error", err)
 			// Treat error as needing update
 			guildVerTime = nil
 		}
 
-		needsUpdate := force || guildVerTime == nil || localVer.BuildTime.After(*guildVerTime)
-
-		if !needsUpdate {
-			slog.Debug("Skipping command update (already up to date)", "name", def.Name, "guild_id", guildID)
-			skipCount++
-			continue
+		if force || guildVerTime == nil || localVer.BuildTime.After(*guildVerTime) {
+			outdated = append(outdated, name)
 		}
+	}
 
-		existing, exists := existingMap[def.Name]
-		var syncErr error
-		if exists {
-			slog.Info("Updating command", "name", def.Name, "guild_id", guildID)
-			_, syncErr = session.ApplicationCommandEdit(session.State.User.ID, guildID, existing.ID, def)
-		} else {
-			slog.Info("Creating new command", "name", def.Name, "guild_id", guildID)
-			_, syncErr = session.ApplicationCommandCreate(session.State.User.ID, guildID, def)
-		}
+	// Whatever is left on Discord is no longer in the registry
+	if !force && len(outdated) == 0 && len(existingMap) == 0 {
+		slog.Info("Command sync skipped (already up to date)", "guild_id", guildID, "total", len(definitions))
+		return nil
+	}
 
-		if syncErr != nil {
-			slog.Error("Failed to sync command", "name", def.Name, "guild_id", guildID, "error", syncErr)
-			failedCommands = append(failedCommands, fmt.Sprintf("%s: %v", def.Name, syncErr))
-		} else {
-			successCount++
-			// Update guild version time
-			if err := mgr.UpdateGuildCommandLastVersionTime(guildID, def.Name, time.Now().UTC()); err != nil {
-				slog.Error("Failed to update guild command version time", "guild_id", guildID, "name", def.Name, "error", err)
-			}
+	if _, err := session.ApplicationCommandBulkOverwrite(session.State.User.ID, guildID, definitions); err != nil {
+		slog.Error("Failed to overwrite commands", "guild_id", guildID, "error", err)
+		return fmt.Errorf("failed to overwrite commands: %w", err)
+	}
+
+	now := time.Now().UTC()
+	for _, def := range definitions {
+		name := Key(def)
+		if err := mgr.UpdateGuildCommandLastVersionTime(guildID, name, now); err != nil {
+			slog.Error("Failed to update guild command version time", "guild_id", guildID, "name", name, "error", err)
+		}
+	}
+	for name := range existingMap {
+		slog.Info("Deleted obsolete command", "name", name, "guild_id", guildID)
+		if err := mgr.DeleteGuildCommandVersion(guildID, name); err != nil {
+			slog.Warn("Failed to delete obsolete guild command version from DB", "guild_id", guildID, "name", name, "error", err)
 		}
 	}
 
 	slog.Info("Command sync completed",
 		"guild_id", guildID,
-		"total", len(allCommands),
-		"succeeded", successCount,
-		"failed", len(failedCommands),
-		"skipped", skipCount)
-
-	if len(failedCommands) > 0 {
-		errMsg := fmt.Errorf("failed to sync %d command(s), succeeded: %d/%d. Failed commands: %v",
-			len(failedCommands), successCount, len(allCommands), failedCommands)
-		slog.Error("Command sync had failures", "guild_id", guildID, "error", errMsg)
-		return errMsg
-	}
+		"total", len(definitions),
+		"outdated", len(outdated),
+		"deleted", len(existingMap))
 
 	return nil
 }
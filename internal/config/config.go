@@ -1,48 +1,188 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"reflect"
+	"slices"
+	"sync/atomic"
+	"time"
 
-	"github.com/joho/godotenv"
+	"github.com/bwmarrin/discordgo"
 )
 
-// Config holds all configuration for the application
+// Command registration modes
+const (
+	RegistrationGuild  = "guild"  // Register every command in every guild the bot joins
+	RegistrationGlobal = "global" // Register every command once, for all guilds
+	RegistrationDev    = "dev"    // Register every command in the dev guilds only
+)
+
+// Config holds all configuration for the application. Every key can be set in
+// the config file or overridden by the environment variable of the same name
+// in upper case, e.g. log_level and LOG_LEVEL.
 type Config struct {
-	DiscordToken string
-	LogLevel     string
+	DiscordToken        string        `yaml:"discord_token"`
+	DiscordTokenFile    string        `yaml:"discord_token_file"` // File holding the token, instead of discord_token
+	LogLevel            string        `yaml:"log_level"`
+	DatabasePath        string        `yaml:"database_path"`
+	HTTPTimeout         time.Duration `yaml:"http_timeout"`
+	Intents             []string      `yaml:"intents"`
+	DefaultLocale       string        `yaml:"default_locale"` // Locale for users whose Discord language is not translated
+	I18nDir             string        `yaml:"i18n_dir"`       // Translation files replacing the embedded ones, e.g. resources/i18n
+	OwnerIDs            []string      `yaml:"owner_ids"`      // Users allowed to run owner-only commands
+	CommandRegistration string        `yaml:"command_registration"`
+	DevGuildIDs         []string      `yaml:"dev_guild_ids"` // Guilds that receive the commands in dev mode
+
+	file    string            // Config file that was read, empty if there was none
+	sources map[string]string // Where each key was set, for errors and config print
+}
+
+// intents maps the intent names of the config to their gateway flags
+var intents = map[string]discordgo.Intent{
+	"guilds":                        discordgo.IntentsGuilds,
+	"guild_members":                 discordgo.IntentsGuildMembers,
+	"guild_moderation":              discordgo.IntentsGuildBans,
+	"guild_emojis":                  discordgo.IntentsGuildEmojis,
+	"guild_integrations":            discordgo.IntentsGuildIntegrations,
+	"guild_webhooks":                discordgo.IntentsGuildWebhooks,
+	"guild_invites":                 discordgo.IntentsGuildInvites,
+	"guild_voice_states":            discordgo.IntentsGuildVoiceStates,
+	"guild_presences":               discordgo.IntentsGuildPresences,
+	"guild_messages":                discordgo.IntentsGuildMessages,
+	"guild_message_reactions":       discordgo.IntentsGuildMessageReactions,
+	"guild_message_typing":          discordgo.IntentsGuildMessageTyping,
+	"direct_messages":               discordgo.IntentsDirectMessages,
+	"direct_message_reactions":      discordgo.IntentsDirectMessageReactions,
+	"direct_message_typing":         discordgo.IntentsDirectMessageTyping,
+	"message_content":               discordgo.IntentsMessageContent,
+	"guild_scheduled_events":        discordgo.IntentsGuildScheduledEvents,
+	"auto_moderation_configuration": discordgo.IntentAutoModerationConfiguration,
+	"auto_moderation_execution":     discordgo.IntentAutoModerationExecution,
+}
+
+// defaults returns the configuration used for keys that are not set
+func defaults() *Config {
+	return &Config{
+		LogLevel:     "INFO",
+		DatabasePath: "database.db",
+		HTTPTimeout:  30 * time.Second,
+		// Guilds for slash commands and voice states for /random teams
+		Intents:             []string{"guilds", "guild_voice_states"},
+		DefaultLocale:       "en-US",
+		CommandRegistration: RegistrationGuild,
+	}
 }
 
-var instance *Config
+// LiveKeys take effect when the configuration is reloaded; the other keys are
+// only read on startup
+var LiveKeys = []string{"log_level", "default_locale", "i18n_dir", "owner_ids"}
+
+// instance is replaced as a whole on reload, so readers never see a partial update
+var instance atomic.Pointer[Config]
 
 // Load initializes and returns the application configuration
 func Load() (*Config, error) {
-	if instance != nil {
-		return instance, nil
+	if cfg := instance.Load(); cfg != nil {
+		return cfg, cfg.requireToken()
 	}
 
-	// Load .env file if exists (ignore error if not found)
-	_ = godotenv.Load()
+	cfg, err := Read()
+	if err != nil {
+		return nil, err
+	}
+	instance.Store(cfg)
+	return cfg, nil
+}
 
-	token := os.Getenv("DISCORD_TOKEN")
-	if token == "" {
-		return nil, fmt.Errorf("DISCORD_TOKEN environment variable is required")
+// LoadLocal loads the configuration for tasks that do not connect to Discord,
+// so the token may be unset
+func LoadLocal() (*Config, error) {
+	if cfg := instance.Load(); cfg != nil {
+		return cfg, nil
 	}
 
-	logLevel := os.Getenv("LOG_LEVEL")
-	if logLevel == "" {
-		logLevel = "INFO" // Default to INFO
+	cfg, err := load()
+	if err != nil {
+		return nil, err
 	}
+	instance.Store(cfg)
+	return cfg, nil
+}
 
-	instance = &Config{
-		DiscordToken: token,
-		LogLevel:     logLevel,
+// Read loads and validates the configuration without making it the current one.
+// Environment variables from .env are only read the first time.
+func Read() (*Config, error) {
+	cfg, err := load()
+	if err != nil {
+		return nil, err
 	}
+	return cfg, cfg.requireToken()
+}
 
-	return instance, nil
+// Swap makes cfg the current configuration and returns the previous one
+func Swap(cfg *Config) *Config {
+	return instance.Swap(cfg)
 }
 
 // Get returns the singleton config instance
 func Get() *Config {
-	return instance
+	return instance.Load()
+}
+
+// Changed lists the keys whose values differ between two configurations
+func Changed(old, cfg *Config) []string {
+	var changed []string
+	for _, key := range keys() {
+		if !reflect.DeepEqual(old.field(key).Interface(), cfg.field(key).Interface()) {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
+
+// KeepStartupKeys resets the keys outside LiveKeys to their values in old, so
+// that a reload only changes what takes effect while the bot runs
+func (c *Config) KeepStartupKeys(old *Config) {
+	for _, key := range keys() {
+		if !slices.Contains(LiveKeys, key) {
+			c.field(key).Set(old.field(key))
+			c.sources[key] = old.sources[key]
+		}
+	}
+}
+
+// IsOwner reports whether a user is one of the configured bot owners
+func (c *Config) IsOwner(userID string) bool {
+	return c != nil && slices.Contains(c.OwnerIDs, userID)
+}
+
+// IsDevGuild reports whether a guild is one of the configured dev guilds
+func (c *Config) IsDevGuild(guildID string) bool {
+	return c != nil && slices.Contains(c.DevGuildIDs, guildID)
+}
+
+// IntentFlags combines the configured gateway intents
+func (c *Config) IntentFlags() discordgo.Intent {
+	var flags discordgo.Intent
+	for _, name := range c.Intents {
+		flags |= intents[name]
+	}
+	return flags
+}
+
+func (c *Config) requireToken() error {
+	if c.DiscordToken == "" {
+		return c.errorf("discord_token", "is required, set it or discord_token_file")
+	}
+	return nil
+}
+
+// errorf reports a problem with a key, pointing at where it was set
+func (c *Config) errorf(key, format string, args ...interface{}) error {
+	message := key + " " + fmt.Sprintf(format, args...)
+	if source := c.sources[key]; source != "default" {
+		message = source + ": " + message
+	}
+	return errors.New(message)
 }
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is read when CONFIG_FILE is unset, if it exists
+const defaultConfigFile = "config.yaml"
+
+// secrets pairs each secret key with the key naming a file that holds it
+var secrets = map[string]string{
+	"discord_token": "discord_token_file",
+}
+
+// load builds the configuration from the defaults, the config file and the
+// environment, in increasing priority, then reads secret files and validates it
+func load() (*Config, error) {
+	// Load .env file if exists (ignore error if not found)
+	_ = godotenv.Load()
+
+	cfg := defaults()
+	cfg.sources = make(map[string]string)
+	for _, key := range keys() {
+		cfg.sources[key] = "default"
+	}
+
+	// Once a config file was read, reloading must not fall back to the defaults
+	previous := instance.Load()
+	if err := cfg.loadFile(previous != nil && previous.file != ""); err != nil {
+		return nil, err
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+	if err := cfg.loadSecrets(); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile reads the YAML config file, rejecting unknown keys. The default
+// file may be missing unless required is set.
+func (c *Config) loadFile(required bool) error {
+	path, explicit := os.LookupEnv("CONFIG_FILE")
+	if !explicit || path == "" {
+		path = defaultConfigFile
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	c.file = path
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	// Record the line of every key, so errors can point at it
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if len(root.Content) > 0 {
+		mapping := root.Content[0]
+		for n := 0; n+1 < len(mapping.Content); n += 2 {
+			key := mapping.Content[n]
+			c.sources[key.Value] = fmt.Sprintf("%s:%d", path, key.Line)
+		}
+	}
+	return nil
+}
+
+// loadEnv overrides keys with the environment variables of the same name in
+// upper case. Lists are comma-separated; empty variables are ignored.
+func (c *Config) loadEnv() error {
+	for _, key := range keys() {
+		name := strings.ToUpper(key)
+		value := strings.TrimSpace(os.Getenv(name))
+		if value == "" {
+			continue
+		}
+
+		source := c.sources[key]
+		c.sources[key] = "$" + name
+		field := c.field(key)
+		switch field.Interface().(type) {
+		case string:
+			field.SetString(value)
+		case []string:
+			field.Set(reflect.ValueOf(splitList(value)))
+		case time.Duration:
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return c.errorf(key, "must be a duration such as 30s, got %q", value)
+			}
+			field.SetInt(int64(d))
+		default:
+			c.sources[key] = source
+		}
+	}
+	return nil
+}
+
+// loadSecrets reads secrets given as files. A secret set in the environment
+// wins over one from the config file, whichever of the two keys is used.
+// Relative paths in the config file are relative to the file itself.
+func (c *Config) loadSecrets() error {
+	for valueKey, fileKey := range secrets {
+		value, file := c.field(valueKey), c.field(fileKey)
+		if file.String() == "" {
+			continue
+		}
+		if value.String() != "" {
+			if c.fromEnv(valueKey) == c.fromEnv(fileKey) {
+				return c.errorf(fileKey, "cannot be combined with %s (%s)", valueKey, c.sources[valueKey])
+			}
+			if c.fromEnv(valueKey) {
+				continue
+			}
+		}
+
+		path := file.String()
+		if !filepath.IsAbs(path) && !c.fromEnv(fileKey) {
+			path = filepath.Join(filepath.Dir(c.file), path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return c.errorf(fileKey, "cannot be read: %v", err)
+		}
+		value.SetString(strings.TrimSpace(string(data)))
+		c.sources[valueKey] = c.sources[fileKey]
+	}
+	return nil
+}
+
+// keys lists the config keys in declaration order
+func keys() []string {
+	t := reflect.TypeOf(Config{})
+	var result []string
+	for n := 0; n < t.NumField(); n++ {
+		if key := t.Field(n).Tag.Get("yaml"); key != "" {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// field returns the settable field of a key
+func (c *Config) field(key string) reflect.Value {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for n := 0; n < t.NumField(); n++ {
+		if t.Field(n).Tag.Get("yaml") == key {
+			return v.Field(n)
+		}
+	}
+	panic("unknown config key " + key)
+}
+
+func (c *Config) fromEnv(key string) bool {
+	return strings.HasPrefix(c.sources[key], "$")
+}
+
+// splitList parses a comma-separated list, skipping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
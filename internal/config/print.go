@@ -0,0 +1,39 @@
+package config
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces secret values in printed configuration
+const redacted = "<redacted>"
+
+// Print writes the effective configuration as YAML, with secrets redacted and
+// a comment on every key that was not left at its default
+func (c *Config) Print(w io.Writer) error {
+	printed := *c
+	for valueKey := range secrets {
+		if field := printed.field(valueKey); field.String() != "" {
+			field.SetString(redacted)
+		}
+	}
+
+	var root yaml.Node
+	if err := root.Encode(&printed); err != nil {
+		return err
+	}
+	for n := 0; n+1 < len(root.Content); n += 2 {
+		key := root.Content[n]
+		if source := c.sources[key.Value]; source != "default" {
+			key.LineComment = "from " + source
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&root); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"hiei-discord-bot/internal/i18n"
+)
+
+var logLevels = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+// validate checks every key and normalizes the case of enumerated values,
+// reporting all problems at once
+func (c *Config) validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, c.errorf(key, format, args...))
+		}
+	}
+
+	c.LogLevel = strings.ToUpper(c.LogLevel)
+	check(slices.Contains(logLevels, c.LogLevel), "log_level",
+		"must be one of %s, got %q", strings.Join(logLevels, ", "), c.LogLevel)
+
+	check(c.DatabasePath != "", "database_path", "must not be empty")
+	check(c.HTTPTimeout > 0, "http_timeout", "must be positive, got %s", c.HTTPTimeout)
+
+	for _, name := range c.Intents {
+		check(intents[name] != 0, "intents", "has unknown intent %q, known intents are %s", name, strings.Join(intentNames(), ", "))
+	}
+	check(slices.Contains(c.Intents, "guilds"), "intents", "must include guilds, which slash commands rely on")
+
+	locales := make([]string, 0, len(i18n.Locales))
+	for _, locale := range i18n.Locales {
+		locales = append(locales, string(locale))
+	}
+	check(slices.Contains(locales, c.DefaultLocale), "default_locale",
+		"must be one of %s, got %q", strings.Join(locales, ", "), c.DefaultLocale)
+
+	if c.I18nDir != "" {
+		info, err := os.Stat(c.I18nDir)
+		check(err == nil && info.IsDir(), "i18n_dir", "must be a directory, got %q", c.I18nDir)
+	}
+
+	for _, id := range c.OwnerIDs {
+		check(isSnowflake(id), "owner_ids", "has %q, which is not a user ID", id)
+	}
+
+	c.CommandRegistration = strings.ToLower(c.CommandRegistration)
+	registrations := []string{RegistrationGuild, RegistrationGlobal, RegistrationDev}
+	check(slices.Contains(registrations, c.CommandRegistration), "command_registration",
+		"must be one of %s, got %q", strings.Join(registrations, ", "), c.CommandRegistration)
+	check(c.CommandRegistration != RegistrationDev || len(c.DevGuildIDs) > 0, "dev_guild_ids",
+		"is required when command_registration is %s", RegistrationDev)
+	for _, id := range c.DevGuildIDs {
+		check(isSnowflake(id), "dev_guild_ids", "has %q, which is not a server ID", id)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func intentNames() []string {
+	names := make([]string, 0, len(intents))
+	for name := range intents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isSnowflake reports whether an ID looks like a Discord ID
+func isSnowflake(id string) bool {
+	if id == "" || len(id) > 20 {
+		return false
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
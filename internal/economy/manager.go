@@ -0,0 +1,165 @@
+package economy
+
+import (
+	"errors"
+	"fmt"
+	"hiei-discord-bot/internal/models"
+	"sync"
+	"time"
+)
+
+const (
+	// StartingChips is the balance of a user who has never played
+	StartingChips int64 = 1000
+
+	// DailyRefillChips is the balance a daily refill tops users up to
+	DailyRefillChips int64 = 1000
+)
+
+// ErrInsufficientChips is returned when a debit exceeds the user's balance
+var ErrInsufficientChips = errors.New("insufficient chips")
+
+// Store interface for persistence
+type Store interface {
+	GetChipBalance(userID string) (*models.ChipBalance, error)
+	SetChipBalance(balance models.ChipBalance) error
+}
+
+// Manager handles the virtual chip balance shared by all games
+type Manager struct {
+	store Store
+	mu    sync.Mutex
+}
+
+var instance *Manager
+var once sync.Once
+
+// GetManager returns the singleton manager instance
+func GetManager() *Manager {
+	once.Do(func() {
+		instance = &Manager{}
+	})
+	return instance
+}
+
+// SetStore sets the storage engine
+func (mgr *Manager) SetStore(s Store) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.store = s
+}
+
+// Balance returns the current chip balance of a user
+func (mgr *Manager) Balance(userID string) (int64, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	balance, err := mgr.load(userID)
+	if err != nil {
+		return 0, err
+	}
+	return balance.Balance, nil
+}
+
+// ClaimDailyRefill tops the user's balance up to DailyRefillChips once per UTC day.
+// It returns the number of chips granted, which is zero if the refill was already
+// claimed today or the balance is already high enough.
+func (mgr *Manager) ClaimDailyRefill(userID string) (int64, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	balance, err := mgr.load(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+	if sameDay(balance.LastRefill, now) {
+		return 0, nil
+	}
+
+	granted := DailyRefillChips - balance.Balance
+	if granted < 0 {
+		granted = 0
+	}
+
+	balance.Balance += granted
+	balance.LastRefill = now
+	if err := mgr.store.SetChipBalance(*balance); err != nil {
+		return 0, err
+	}
+	return granted, nil
+}
+
+// Debit removes chips from a user's balance, failing with ErrInsufficientChips
+// if the balance is too low
+func (mgr *Manager) Debit(userID string, amount int64) (int64, error) {
+	if amount < 0 {
+		return 0, fmt.Errorf("invalid debit amount %d", amount)
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	balance, err := mgr.load(userID)
+	if err != nil {
+		return 0, err
+	}
+	if balance.Balance < amount {
+		return balance.Balance, ErrInsufficientChips
+	}
+
+	balance.Balance -= amount
+	if err := mgr.store.SetChipBalance(*balance); err != nil {
+		return 0, err
+	}
+	return balance.Balance, nil
+}
+
+// Credit adds chips to a user's balance
+func (mgr *Manager) Credit(userID string, amount int64) (int64, error) {
+	if amount < 0 {
+		return 0, fmt.Errorf("invalid credit amount %d", amount)
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	balance, err := mgr.load(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	balance.Balance += amount
+	if err := mgr.store.SetChipBalance(*balance); err != nil {
+		return 0, err
+	}
+	return balance.Balance, nil
+}
+
+// load fetches a balance, creating the starting balance for new users.
+// The caller must hold the lock.
+func (mgr *Manager) load(userID string) (*models.ChipBalance, error) {
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+
+	balance, err := mgr.store.GetChipBalance(userID)
+	if err != nil {
+		return nil, err
+	}
+	if balance == nil {
+		balance = &models.ChipBalance{
+			UserID:  userID,
+			Balance: StartingChips,
+		}
+	}
+	return balance, nil
+}
+
+// sameDay reports whether two times fall on the same UTC date
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
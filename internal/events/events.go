@@ -2,6 +2,7 @@ package events
 
 import (
 	"hiei-discord-bot/internal/commands"
+	"hiei-discord-bot/internal/config"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -10,13 +11,15 @@ import (
 type Handler struct {
 	session  *discordgo.Session
 	registry *commands.Registry
+	config   *config.Config
 }
 
 // NewHandler creates a new event handler
-func NewHandler(session *discordgo.Session, registry *commands.Registry) *Handler {
+func NewHandler(session *discordgo.Session, registry *commands.Registry, cfg *config.Config) *Handler {
 	return &Handler{
 		session:  session,
 		registry: registry,
+		config:   cfg,
 	}
 }
 
@@ -2,6 +2,7 @@ package events
 
 import (
 	"hiei-discord-bot/internal/commands"
+	"hiei-discord-bot/internal/config"
 	"log/slog"
 
 	"github.com/bwmarrin/discordgo"
@@ -16,6 +17,20 @@ func (h *Handler) OnGuildCreate(s *discordgo.Session, g *discordgo.GuildCreate)
 		"owner_id", g.OwnerID,
 	)
 
+	// Only per-guild registration, or a dev guild in dev mode, syncs here;
+	// elsewhere commands left by an earlier mode are removed
+	registration := h.config.CommandRegistration
+	if registration == config.RegistrationGlobal || (registration == config.RegistrationDev && !h.config.IsDevGuild(g.ID)) {
+		if err := commands.RemoveGuildCommands(s, g.ID); err != nil {
+			slog.Error("Failed to remove guild commands",
+				"guild_id", g.ID,
+				"guild_name", g.Name,
+				"error", err,
+			)
+		}
+		return
+	}
+
 	if err := commands.SyncGuildCommands(s, h.registry, g.ID, false); err != nil {
 		slog.Error("Failed to sync commands for new guild",
 			"guild_id", g.ID,
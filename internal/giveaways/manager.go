@@ -0,0 +1,256 @@
+package giveaways
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"hiei-discord-bot/internal/models"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNotFound is returned when no giveaway has the given ID
+	ErrNotFound = errors.New("giveaway not found")
+
+	// ErrEnded is returned when entering or ending a giveaway that has already ended
+	ErrEnded = errors.New("giveaway already ended")
+
+	// ErrNotEnded is returned when re-rolling a giveaway that is still running
+	ErrNotEnded = errors.New("giveaway has not ended")
+
+	// ErrAlreadyEntered is returned when a user enters the same giveaway twice
+	ErrAlreadyEntered = errors.New("already entered")
+)
+
+// Store interface for persistence
+type Store interface {
+	SaveGiveaway(giveaway models.Giveaway) error
+	GetGiveaway(id string) (*models.Giveaway, error)
+	ListActiveGiveaways(guildID string) ([]models.Giveaway, error)
+	ListPendingGiveaways() ([]models.Giveaway, error)
+	AddGiveawayEntry(giveawayID, userID string, enteredAt time.Time) (bool, error)
+	GetGiveawayEntries(giveawayID string) ([]string, error)
+	CountGiveawayEntries(giveawayID string) (int, error)
+}
+
+// Manager handles giveaways, their entries and winner draws
+type Manager struct {
+	store Store
+	mu    sync.Mutex
+}
+
+var instance *Manager
+var once sync.Once
+
+// GetManager returns the singleton manager instance
+func GetManager() *Manager {
+	once.Do(func() {
+		instance = &Manager{}
+	})
+	return instance
+}
+
+// SetStore sets the storage engine
+func (mgr *Manager) SetStore(s Store) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.store = s
+}
+
+// NewID returns a short ID that admins can type in /giveaway end and reroll
+func NewID() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+}
+
+// Create stores a new giveaway
+func (mgr *Manager) Create(giveaway *models.Giveaway) error {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+
+	giveaway.CreatedAt = time.Now().UTC()
+	return mgr.store.SaveGiveaway(*giveaway)
+}
+
+// Get retrieves a giveaway by ID
+func (mgr *Manager) Get(id string) (*models.Giveaway, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	return mgr.get(id)
+}
+
+// Active returns the running giveaways of a guild, ending soonest first
+func (mgr *Manager) Active(guildID string) ([]models.Giveaway, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return mgr.store.ListActiveGiveaways(guildID)
+}
+
+// Pending returns every giveaway that has not been drawn yet, across all guilds
+func (mgr *Manager) Pending() ([]models.Giveaway, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return mgr.store.ListPendingGiveaways()
+}
+
+// EntryCount returns the number of entries of a giveaway
+func (mgr *Manager) EntryCount(id string) (int, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return 0, fmt.Errorf("store not initialized")
+	}
+	return mgr.store.CountGiveawayEntries(id)
+}
+
+// Enter adds a user to a running giveaway and returns the new number of entries
+func (mgr *Manager) Enter(id, userID string) (int, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	giveaway, err := mgr.get(id)
+	if err != nil {
+		return 0, err
+	}
+	if giveaway.Ended || time.Now().After(giveaway.EndsAt) {
+		return 0, ErrEnded
+	}
+
+	added, err := mgr.store.AddGiveawayEntry(id, userID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if !added {
+		return 0, ErrAlreadyEntered
+	}
+	return mgr.store.CountGiveawayEntries(id)
+}
+
+// End draws the winners of a running giveaway and marks it as ended.
+// Fewer winners than requested are drawn if there are not enough entries.
+func (mgr *Manager) End(id string) (*models.Giveaway, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	giveaway, err := mgr.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if giveaway.Ended {
+		return nil, ErrEnded
+	}
+
+	entries, err := mgr.store.GetGiveawayEntries(id)
+	if err != nil {
+		return nil, err
+	}
+
+	winners, err := pickWinners(entries, giveaway.WinnerCount, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	giveaway.Ended = true
+	giveaway.WinnerIDs = winners
+	if err := mgr.store.SaveGiveaway(*giveaway); err != nil {
+		return nil, err
+	}
+	return giveaway, nil
+}
+
+// Reroll replaces the winners of an ended giveaway with count new winners,
+// never drawing the current winners again
+func (mgr *Manager) Reroll(id string, count int) (*models.Giveaway, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	giveaway, err := mgr.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !giveaway.Ended {
+		return nil, ErrNotEnded
+	}
+
+	entries, err := mgr.store.GetGiveawayEntries(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if count < 1 {
+		count = giveaway.WinnerCount
+	}
+	winners, err := pickWinners(entries, count, giveaway.WinnerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	giveaway.WinnerIDs = winners
+	if err := mgr.store.SaveGiveaway(*giveaway); err != nil {
+		return nil, err
+	}
+	return giveaway, nil
+}
+
+// get loads a giveaway. The caller must hold the lock.
+func (mgr *Manager) get(id string) (*models.Giveaway, error) {
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+
+	giveaway, err := mgr.store.GetGiveaway(id)
+	if err != nil {
+		return nil, err
+	}
+	if giveaway == nil {
+		return nil, ErrNotFound
+	}
+	return giveaway, nil
+}
+
+// pickWinners draws up to count distinct entries with crypto/rand, skipping excluded users
+func pickWinners(entries []string, count int, exclude []string) ([]string, error) {
+	excluded := make(map[string]bool, len(exclude))
+	for _, userID := range exclude {
+		excluded[userID] = true
+	}
+
+	var pool []string
+	for _, userID := range entries {
+		if !excluded[userID] {
+			pool = append(pool, userID)
+		}
+	}
+
+	// Partial Fisher-Yates shuffle: the first count positions are the winners
+	if count > len(pool) {
+		count = len(pool)
+	}
+	for idx := 0; idx < count; idx++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(pool)-idx)))
+		if err != nil {
+			return nil, err
+		}
+		swap := idx + int(n.Int64())
+		pool[idx], pool[swap] = pool[swap], pool[idx]
+	}
+	return pool[:count], nil
+}
@@ -0,0 +1,84 @@
+package highscores
+
+import (
+	"fmt"
+	"hiei-discord-bot/internal/models"
+	"sync"
+	"time"
+)
+
+// Store interface for persistence
+type Store interface {
+	GetBestScore(game, userID string) (*models.BestScore, error)
+	SetBestScore(score models.BestScore) error
+}
+
+// Manager records the best score of each user per game
+type Manager struct {
+	store Store
+	mu    sync.Mutex
+}
+
+var instance *Manager
+var once sync.Once
+
+// GetManager returns the singleton manager instance
+func GetManager() *Manager {
+	once.Do(func() {
+		instance = &Manager{}
+	})
+	return instance
+}
+
+// SetStore sets the storage engine
+func (mgr *Manager) SetStore(s Store) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.store = s
+}
+
+// Best returns the best score of a user for a game, or zero if none was recorded
+func (mgr *Manager) Best(game, userID string) (int64, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return 0, fmt.Errorf("store not initialized")
+	}
+
+	best, err := mgr.store.GetBestScore(game, userID)
+	if err != nil || best == nil {
+		return 0, err
+	}
+	return best.Score, nil
+}
+
+// Submit records a finished game's score and returns the user's best score
+// along with whether the submitted score is a new record
+func (mgr *Manager) Submit(game, userID string, score int64) (int64, bool, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.store == nil {
+		return 0, false, fmt.Errorf("store not initialized")
+	}
+
+	best, err := mgr.store.GetBestScore(game, userID)
+	if err != nil {
+		return 0, false, err
+	}
+	if best != nil && best.Score >= score {
+		return best.Score, false, nil
+	}
+
+	err = mgr.store.SetBestScore(models.BestScore{
+		Game:       game,
+		UserID:     userID,
+		Score:      score,
+		AchievedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return score, true, nil
+}
@@ -0,0 +1,82 @@
+// Package hotreload applies configuration and translation changes while the
+// bot runs, so games in progress survive them
+package hotreload
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"hiei-discord-bot/internal/config"
+	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/logging"
+)
+
+// Result describes an applied reload
+type Result struct {
+	Applied         []string // Changed keys that took effect
+	RestartRequired []string // Changed keys that only take effect after a restart
+}
+
+// maxReportedProblems limits the translation problems listed in a reload error
+const maxReportedProblems = 5
+
+// mu serializes reloads triggered by signals and commands
+var mu sync.Mutex
+
+// Reload re-reads the config file and the translations. Both are validated
+// before anything is replaced, so a broken file keeps the previous version.
+func Reload() (Result, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	cfg, err := config.Read()
+	if err != nil {
+		return Result{}, err
+	}
+	translations, err := i18n.ReadTranslationsFrom(cfg.I18nDir)
+	if err != nil {
+		return Result{}, err
+	}
+	if problems := translations.Check(); len(problems) > 0 {
+		return Result{}, problemsError(problems)
+	}
+
+	var result Result
+	if old := config.Get(); old != nil {
+		for _, key := range config.Changed(old, cfg) {
+			if slices.Contains(config.LiveKeys, key) {
+				result.Applied = append(result.Applied, key)
+			} else {
+				result.RestartRequired = append(result.RestartRequired, key)
+			}
+		}
+		// Code that read the other keys on startup keeps using those values,
+		// so the current configuration must keep reporting them until a restart
+		cfg.KeepStartupKeys(old)
+	}
+
+	i18n.UseTranslations(translations)
+	config.Swap(cfg)
+	logging.SetLevel(cfg.LogLevel)
+	i18n.SetDefaultLocale(i18n.SupportedLocale(cfg.DefaultLocale))
+
+	slog.Info("Reloaded configuration and translations", "applied", result.Applied)
+	if len(result.RestartRequired) > 0 {
+		slog.Warn("Some configuration changes need a restart", "keys", result.RestartRequired)
+	}
+	return result, nil
+}
+
+// problemsError reports the translation problems that rejected a reload
+func problemsError(problems []string) error {
+	listed := problems[:min(len(problems), maxReportedProblems)]
+	message := fmt.Sprintf("translations have %d problem(s):\n%s", len(problems), strings.Join(listed, "\n"))
+	if len(problems) > len(listed) {
+		message += "\n…"
+	}
+	return errors.New(message)
+}
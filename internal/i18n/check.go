@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// verbPattern matches fmt verbs, including explicit argument indexes such as %[2]d
+var verbPattern = regexp.MustCompile(`%(?:\[(\d+)\])?[-+# 0]*\d*(?:\.\d+)?([a-zA-Z%])`)
+
+// Check reports the problems of the current translations, see Translations.Check
+func Check() []string {
+	return current().Check()
+}
+
+// Check compares every locale against English, the fallback locale, and reports
+// keys that are not translated or whose format verbs do not match.
+// Keys that only exist in a translation are allowed.
+func (t Translations) Check() []string {
+	reference := flatten(t[LocaleEnUS], "")
+	keys := make([]string, 0, len(reference))
+	for key := range reference {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, locale := range Locales {
+		if locale == LocaleEnUS {
+			continue
+		}
+		values := flatten(t[locale], "")
+		for _, key := range keys {
+			value, ok := values[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: %s is not translated", locale, key))
+				continue
+			}
+			if want, got := verbs(reference[key]), verbs(value); want != got {
+				problems = append(problems, fmt.Sprintf("%s: %s uses %s, %s uses %s", locale, key, got, LocaleEnUS, want))
+			}
+		}
+	}
+	return problems
+}
+
+// flatten maps the dotted key of every string in a translation tree to its value
+func flatten(data map[string]interface{}, prefix string) map[string]string {
+	result := make(map[string]string)
+	for key, value := range data {
+		switch v := value.(type) {
+		case string:
+			result[prefix+key] = v
+		case map[string]interface{}:
+			for nested, text := range flatten(v, prefix+key+".") {
+				result[nested] = text
+			}
+		}
+	}
+	return result
+}
+
+// verbs describes the arguments a format string consumes, e.g. "[1:s 2:d]",
+// so translations that reorder arguments with %[n] still match
+func verbs(format string) string {
+	args := make(map[int]byte)
+	next := 1
+	for _, match := range verbPattern.FindAllStringSubmatch(format, -1) {
+		if match[2] == "%" {
+			continue
+		}
+		if match[1] != "" {
+			next, _ = strconv.Atoi(match[1])
+		}
+		args[next] = match[2][0]
+		next++
+	}
+
+	indexes := make([]int, 0, len(args))
+	for index := range args {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	result := "["
+	for n, index := range indexes {
+		if n > 0 {
+			result += " "
+		}
+		result += fmt.Sprintf("%d:%c", index, args[index])
+	}
+	return result + "]"
+}
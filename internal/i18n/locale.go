@@ -3,6 +3,7 @@ package i18n
 import (
 	"hiei-discord-bot/internal/settings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -24,6 +25,23 @@ type LocaleStore struct {
 var store *LocaleStore
 var once sync.Once
 
+// defaultLocale holds the SupportedLocale of users whose Discord language has
+// no translation; it can change while the bot runs
+var defaultLocale atomic.Value
+
+// SetDefaultLocale changes the locale of users whose Discord language has no translation
+func SetDefaultLocale(locale SupportedLocale) {
+	defaultLocale.Store(locale)
+}
+
+// DefaultLocale returns the locale of users whose Discord language has no translation
+func DefaultLocale() SupportedLocale {
+	if locale, ok := defaultLocale.Load().(SupportedLocale); ok {
+		return locale
+	}
+	return LocaleEnUS
+}
+
 // GetStore returns the singleton locale store
 func GetStore() *LocaleStore {
 	once.Do(func() {
@@ -82,9 +100,11 @@ func convertToSupportedLocale(discordLocale discordgo.Locale) SupportedLocale {
 	switch discordLocale {
 	case discordgo.ChineseTW:
 		return LocaleZhTW
-	default:
-		// Default to English for all other locales (including zh-CN)
+	case discordgo.EnglishUS, discordgo.EnglishGB:
 		return LocaleEnUS
+	default:
+		// Fall back to the configured locale for all other locales (including zh-CN)
+		return DefaultLocale()
 	}
 }
 
@@ -98,7 +118,7 @@ func GetUserLocaleFromInteraction(i *discordgo.InteractionCreate) SupportedLocal
 	}
 
 	if userID == "" {
-		return LocaleEnUS
+		return DefaultLocale()
 	}
 
 	store := GetStore()
@@ -3,43 +3,93 @@ package i18n
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"log/slog"
+	"os"
+	"path"
 	"strings"
+	"sync"
 
 	"hiei-discord-bot/resources"
 )
 
-// translations stores all loaded translations
-var translations map[SupportedLocale]map[string]interface{}
+// Translations holds the parsed translation file of every locale
+type Translations map[SupportedLocale]map[string]interface{}
 
-// LoadTranslations loads all translation files
+// translations stores all loaded translations. Loading replaces the whole map,
+// so a map read under the lock stays consistent after it is released.
+var (
+	translations   Translations
+	translationsMu sync.RWMutex
+)
+
+// Locales lists the locales with a translation file
+var Locales = []SupportedLocale{LocaleZhTW, LocaleEnUS}
+
+// LoadTranslations loads all embedded translation files
 func LoadTranslations() error {
-	translations = make(map[SupportedLocale]map[string]interface{})
+	return LoadTranslationsFrom("")
+}
 
-	locales := []SupportedLocale{LocaleZhTW, LocaleEnUS}
+// LoadTranslationsFrom loads all translation files from dir, or the embedded
+// files when dir is empty. The current translations are only replaced once every
+// file has been read, so a broken file keeps the previous version.
+func LoadTranslationsFrom(dir string) error {
+	loaded, err := ReadTranslationsFrom(dir)
+	if err != nil {
+		return err
+	}
+	UseTranslations(loaded)
+	return nil
+}
+
+// ReadTranslationsFrom reads all translation files from dir, or the embedded
+// files when dir is empty, without making them the current translations
+func ReadTranslationsFrom(dir string) (Translations, error) {
+	var files fs.FS = resources.I18n
+	base := resources.I18nBasePath
+	if dir != "" {
+		files = os.DirFS(dir)
+		base = "."
+	}
 
-	for _, locale := range locales {
-		filename := fmt.Sprintf("%s/%s.json", resources.I18nBasePath, locale)
-		data, err := resources.I18n.ReadFile(filename)
+	loaded := make(Translations)
+	for _, locale := range Locales {
+		filename := path.Join(base, string(locale)+".json")
+		data, err := fs.ReadFile(files, filename)
 		if err != nil {
-			return fmt.Errorf("failed to read translation file %s: %w", locale, err)
+			return nil, fmt.Errorf("failed to read translation file %s: %w", locale, err)
 		}
 
 		var translationData map[string]interface{}
 		if err := json.Unmarshal(data, &translationData); err != nil {
-			return fmt.Errorf("failed to parse translation file %s: %w", locale, err)
+			return nil, fmt.Errorf("failed to parse translation file %s: %w", locale, err)
 		}
 
-		translations[locale] = translationData
-		slog.Info("Loaded translations", "locale", locale, "path", filename)
+		loaded[locale] = translationData
+		slog.Info("Loaded translations", "locale", locale, "path", path.Join(dir, filename))
 	}
+	return loaded, nil
+}
 
-	return nil
+// UseTranslations makes loaded the current translations
+func UseTranslations(loaded Translations) {
+	translationsMu.Lock()
+	translations = loaded
+	translationsMu.Unlock()
+}
+
+// current returns the loaded translations
+func current() Translations {
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+	return translations
 }
 
 // T translates a key to the target locale
 // Key format: "section.subsection.key" (e.g., "game.bullsandcows.title")
 func T(locale SupportedLocale, key string) string {
+	translations := current()
 	value := getNestedValue(translations[locale], key)
 	if value != "" {
 		return value
@@ -55,6 +105,14 @@ func T(locale SupportedLocale, key string) string {
 	return key
 }
 
+// TDefault translates a key, returning fallback when no translation exists
+func TDefault(locale SupportedLocale, key, fallback string) string {
+	if value := T(locale, key); value != key {
+		return value
+	}
+	return fallback
+}
+
 // Tf translates a key with format arguments
 func Tf(locale SupportedLocale, key string, args ...interface{}) string {
 	template := T(locale, key)
@@ -41,7 +41,7 @@ func (r *Router) RegisterComponent(prefix string, handler ComponentHandler) {
 	defer r.mu.Unlock()
 
 	r.components[prefix] = handler
-	slog.Info("Registered component handler", "prefix", prefix)
+	slog.Debug("Registered component handler", "prefix", prefix)
 }
 
 // RegisterModal registers a modal interaction handler
@@ -50,7 +50,7 @@ func (r *Router) RegisterModal(prefix string, handler ModalHandler) {
 	defer r.mu.Unlock()
 
 	r.modals[prefix] = handler
-	slog.Info("Registered modal handler", "prefix", prefix)
+	slog.Debug("Registered modal handler", "prefix", prefix)
 }
 
 // HandleComponent handles a component interaction
@@ -43,3 +43,26 @@ func RespondCustom(s *discordgo.Session, i *discordgo.InteractionCreate, data *d
 		Data: data,
 	})
 }
+
+// User returns the user who triggered an interaction, in a guild or in a DM
+func User(i *discordgo.InteractionCreate) *discordgo.User {
+	if i == nil {
+		return nil
+	}
+
+	// Guild interaction (Member takes priority)
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+
+	// DM interaction
+	return i.User
+}
+
+// UserID returns the ID of the user who triggered an interaction, or "" if unknown
+func UserID(i *discordgo.InteractionCreate) string {
+	if user := User(i); user != nil {
+		return user.ID
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+// Package logging configures the default slog logger, whose level can change
+// while the bot runs
+package logging
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// level is shared by the installed handler, so SetLevel takes effect immediately
+var level = new(slog.LevelVar)
+
+// Setup installs a text logger on stdout with the given level name
+func Setup(name string) {
+	SetLevel(name)
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: level,
+	})))
+}
+
+// SetLevel changes the level of the logger installed by Setup
+func SetLevel(name string) {
+	level.Set(ParseLevel(name))
+}
+
+// ParseLevel converts DEBUG, INFO, WARN or ERROR to a level, defaulting to INFO
+func ParseLevel(name string) slog.Level {
+	switch strings.ToUpper(name) {
+	case "DEBUG":
+		return slog.LevelDebug
+	case "WARN":
+		return slog.LevelWarn
+	case "ERROR":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+type Blame struct {
+	ID        int64
+	GuildID   string
+	ChannelID string
+	MessageID string
+	BlamerID  string
+	TargetID  string
+	Reason    string
+	CreatedAt time.Time
+}
+
+type BlameReasonCount struct {
+	Reason string
+	Count  int
+}
+
+type BlameUserCount struct {
+	UserID string
+	Count  int
+}
+
+type BlameImage struct {
+	ID          int64
+	GuildID     string
+	Name        string
+	ContentType string
+	Size        int
+	Data        []byte
+	UploaderID  string
+	CreatedAt   time.Time
+}
+
+type BlameVote struct {
+	ID        string
+	BlameID   int64
+	Kind      string
+	GuildID   string
+	ChannelID string
+	MessageID string
+	TargetID  string
+	BlamerID  string
+	Reason    string
+	Threshold int
+	Status    string
+	Locale    string
+	EndsAt    time.Time
+	CreatedAt time.Time
+}
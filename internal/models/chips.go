@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+type ChipBalance struct {
+	UserID     string
+	Balance    int64
+	LastRefill time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type Giveaway struct {
+	ID             string
+	GuildID        string
+	ChannelID      string
+	MessageID      string
+	HostID         string
+	Prize          string
+	WinnerCount    int
+	RequiredRoleID string
+	Locale         string
+	EndsAt         time.Time
+	Ended          bool
+	WinnerIDs      []string
+	CreatedAt      time.Time
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type BestScore struct {
+	Game       string
+	UserID     string
+	Score      int64
+	AchievedAt time.Time
+}
@@ -4,5 +4,6 @@ import "time"
 
 type CommandVersion struct {
 	Version   string
+	Hash      string // Hash of the canonical definition, empty for rows written before hashing
 	BuildTime time.Time
 }
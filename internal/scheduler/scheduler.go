@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Job is a scheduled task run with the bot's session
+type Job func(s *discordgo.Session)
+
+// Scheduler runs jobs at a given time. Jobs live in memory only; features that
+// must survive restarts persist their own state and reschedule it from a start hook.
+type Scheduler struct {
+	session *discordgo.Session
+	timers  map[string]*time.Timer // job ID -> timer
+	hooks   []Job
+	mu      sync.Mutex
+}
+
+var instance *Scheduler
+var once sync.Once
+
+// Get returns the singleton scheduler
+func Get() *Scheduler {
+	once.Do(func() {
+		instance = &Scheduler{
+			timers: make(map[string]*time.Timer),
+		}
+	})
+	return instance
+}
+
+// OnStart registers a hook that runs once the bot has connected,
+// typically to reschedule jobs loaded from the database
+func (sc *Scheduler) OnStart(hook Job) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.hooks = append(sc.hooks, hook)
+}
+
+// Start sets the session jobs run with and runs the start hooks
+func (sc *Scheduler) Start(s *discordgo.Session) {
+	sc.mu.Lock()
+	sc.session = s
+	hooks := sc.hooks
+	sc.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(s)
+	}
+	slog.Info("Scheduler started", "hooks", len(hooks))
+}
+
+// Schedule runs the job at the given time, replacing any job with the same ID.
+// Jobs scheduled in the past run immediately.
+func (sc *Scheduler) Schedule(id string, at time.Time, job Job) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if timer, exists := sc.timers[id]; exists {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Until(at), func() {
+		sc.mu.Lock()
+		// Skip if the job was cancelled or replaced after the timer fired
+		if sc.timers[id] != timer {
+			sc.mu.Unlock()
+			return
+		}
+		delete(sc.timers, id)
+		session := sc.session
+		sc.mu.Unlock()
+
+		slog.Info("Running scheduled job", "id", id)
+		job(session)
+	})
+	sc.timers[id] = timer
+}
+
+// Cancel stops a scheduled job
+func (sc *Scheduler) Cancel(id string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if timer, exists := sc.timers[id]; exists {
+		timer.Stop()
+		delete(sc.timers, id)
+	}
+}
+
+// Stop cancels all scheduled jobs
+func (sc *Scheduler) Stop() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	for id, timer := range sc.timers {
+		timer.Stop()
+		delete(sc.timers, id)
+	}
+}
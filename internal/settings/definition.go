@@ -1,5 +1,7 @@
 package settings
 
+import "fmt"
+
 // SettingScope defines the scope of a setting
 type SettingScope string
 
@@ -17,6 +19,7 @@ const (
 	TypeBool    SettingType = "bool"
 	TypeSelect  SettingType = "select"
 	TypeChannel SettingType = "channel"
+	TypeText    SettingType = "text" // Multi-line string
 )
 
 // SettingDefinition defines a single setting item
@@ -33,6 +36,20 @@ type SettingDefinition struct {
 	RequiredPermission int64  // Required permission for ScopeGuild
 }
 
+// IntRange returns a validator accepting integers between min and max inclusive
+func IntRange(min, max int) func(val interface{}) error {
+	return func(val interface{}) error {
+		var n int
+		if _, err := fmt.Sscanf(fmt.Sprintf("%v", val), "%d", &n); err != nil {
+			return fmt.Errorf("%v is not a number", val)
+		}
+		if n < min || n > max {
+			return fmt.Errorf("%d is not between %d and %d", n, min, max)
+		}
+		return nil
+	}
+}
+
 // Configurable is an interface for commands that have settings
 type Configurable interface {
 	Settings() []SettingDefinition
@@ -25,6 +25,7 @@ type Store interface {
 	GetAllLocalCommandNames() ([]string, error)
 	DeleteLocalCommandVersion(command_name string) error
 	DeleteGuildCommandVersion(guild_id string, command_name string) error
+	GetGuildCommandNames(guild_id string) ([]string, error)
 }
 
 var instance *Manager
@@ -161,6 +162,13 @@ func (mgr *Manager) DeleteGuildCommandVersion(guildID string, commandName string
 	return mgr.store.DeleteGuildCommandVersion(guildID, commandName)
 }
 
+func (mgr *Manager) GetGuildCommandNames(guildID string) ([]string, error) {
+	if mgr.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return mgr.store.GetGuildCommandNames(guildID)
+}
+
 func (mgr *Manager) convertSettingValue(val string, t SettingType) (interface{}, error) {
 	switch t {
 	case TypeInt:
@@ -0,0 +1,295 @@
+package store
+
+import (
+	"database/sql"
+	"hiei-discord-bot/internal/models"
+	"time"
+)
+
+// confirmedBlame excludes blames that failed their vote, are still being voted on,
+// or were overturned on appeal
+const confirmedBlame = `
+	NOT EXISTS (
+		SELECT 1 FROM blame_votes v
+		WHERE v.blame_id = blames.id
+		AND ((v.kind = 'blame' AND v.status <> 'passed') OR (v.kind = 'appeal' AND v.status = 'passed'))
+	)`
+
+func (s *SQLiteStore) AddBlame(blame models.Blame) (int64, error) {
+	query := `
+	INSERT INTO blames (guild_id, channel_id, message_id, blamer_id, target_id, reason, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?);
+	`
+	result, err := s.db.Exec(query,
+		blame.GuildID,
+		blame.ChannelID,
+		blame.MessageID,
+		blame.BlamerID,
+		blame.TargetID,
+		blame.Reason,
+		blame.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (s *SQLiteStore) CountBlamesReceived(guild_id string, user_id string) (int, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM blames WHERE guild_id = ? AND target_id = ? AND" + confirmedBlame
+	err := s.db.QueryRow(query, guild_id, user_id).Scan(&count)
+	return count, err
+}
+
+func (s *SQLiteStore) CountBlamesIssued(guild_id string, user_id string) (int, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM blames WHERE guild_id = ? AND blamer_id = ? AND" + confirmedBlame
+	err := s.db.QueryRow(query, guild_id, user_id).Scan(&count)
+	return count, err
+}
+
+// TopBlameReasons groups reasons case-insensitively, showing the most recent spelling
+func (s *SQLiteStore) TopBlameReasons(guild_id string, target_id string, limit int) ([]models.BlameReasonCount, error) {
+	query := `
+	SELECT reason, COUNT(*) AS count, MAX(created_at) AS last_used
+	FROM blames
+	WHERE guild_id = ? AND target_id = ? AND` + confirmedBlame + `
+	GROUP BY LOWER(TRIM(reason))
+	ORDER BY count DESC, last_used DESC
+	LIMIT ?;
+	`
+	rows, err := s.db.Query(query, guild_id, target_id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reasons []models.BlameReasonCount
+	for rows.Next() {
+		var reason models.BlameReasonCount
+		var last_used string
+		if err := rows.Scan(&reason.Reason, &reason.Count, &last_used); err != nil {
+			return nil, err
+		}
+		reasons = append(reasons, reason)
+	}
+	return reasons, rows.Err()
+}
+
+func (s *SQLiteStore) MostBlamedUsers(guild_id string, since time.Time, limit int) ([]models.BlameUserCount, error) {
+	query := `
+	SELECT target_id, COUNT(*) AS count
+	FROM blames
+	WHERE guild_id = ? AND created_at >= ? AND` + confirmedBlame + `
+	GROUP BY target_id
+	ORDER BY count DESC, MAX(created_at) DESC
+	LIMIT ?;
+	`
+	rows, err := s.db.Query(query, guild_id, since.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []models.BlameUserCount
+	for rows.Next() {
+		var user models.BlameUserCount
+		if err := rows.Scan(&user.UserID, &user.Count); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (s *SQLiteStore) AddBlameImage(image models.BlameImage) (int64, error) {
+	query := `
+	INSERT INTO blame_images (guild_id, name, content_type, data, uploader_id, created_at)
+	VALUES (?, ?, ?, ?, ?, ?);
+	`
+	result, err := s.db.Exec(query,
+		image.GuildID,
+		image.Name,
+		image.ContentType,
+		image.Data,
+		image.UploaderID,
+		image.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// ListBlameImages returns the images of a guild without their data
+func (s *SQLiteStore) ListBlameImages(guild_id string) ([]models.BlameImage, error) {
+	query := `
+	SELECT id, guild_id, name, content_type, LENGTH(data), uploader_id, created_at
+	FROM blame_images
+	WHERE guild_id = ?
+	ORDER BY id;
+	`
+	rows, err := s.db.Query(query, guild_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var images []models.BlameImage
+	for rows.Next() {
+		var image models.BlameImage
+		var created_at string
+		if err := rows.Scan(&image.ID, &image.GuildID, &image.Name, &image.ContentType, &image.Size, &image.UploaderID, &created_at); err != nil {
+			return nil, err
+		}
+		image.CreatedAt, _ = time.Parse(time.RFC3339, created_at)
+		images = append(images, image)
+	}
+	return images, rows.Err()
+}
+
+func (s *SQLiteStore) GetBlameImage(guild_id string, id int64) (*models.BlameImage, error) {
+	query := `
+	SELECT id, guild_id, name, content_type, data, uploader_id, created_at
+	FROM blame_images
+	WHERE guild_id = ? AND id = ?;
+	`
+	var image models.BlameImage
+	var created_at string
+	err := s.db.QueryRow(query, guild_id, id).Scan(&image.ID, &image.GuildID, &image.Name, &image.ContentType, &image.Data, &image.UploaderID, &created_at)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	image.Size = len(image.Data)
+	image.CreatedAt, _ = time.Parse(time.RFC3339, created_at)
+	return &image, nil
+}
+
+func (s *SQLiteStore) DeleteBlameImage(guild_id string, id int64) (bool, error) {
+	query := "DELETE FROM blame_images WHERE guild_id = ? AND id = ?"
+	result, err := s.db.Exec(query, guild_id, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+const blameVoteColumns = "id, blame_id, kind, guild_id, channel_id, message_id, target_id, blamer_id, reason, threshold, status, locale, ends_at, created_at"
+
+func (s *SQLiteStore) SaveBlameVote(vote models.BlameVote) error {
+	query := `
+	INSERT INTO blame_votes (` + blameVoteColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(id)
+	DO UPDATE SET
+		status = excluded.status;
+	`
+	_, err := s.db.Exec(query,
+		vote.ID,
+		vote.BlameID,
+		vote.Kind,
+		vote.GuildID,
+		vote.ChannelID,
+		vote.MessageID,
+		vote.TargetID,
+		vote.BlamerID,
+		vote.Reason,
+		vote.Threshold,
+		vote.Status,
+		vote.Locale,
+		vote.EndsAt.UTC().Format(time.RFC3339),
+		vote.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	return err
+}
+
+func (s *SQLiteStore) GetBlameVote(id string) (*models.BlameVote, error) {
+	query := "SELECT " + blameVoteColumns + " FROM blame_votes WHERE id = ?"
+	vote, err := scanBlameVote(s.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return vote, err
+}
+
+func (s *SQLiteStore) GetBlameVoteByBlame(blame_id int64, kind string) (*models.BlameVote, error) {
+	query := "SELECT " + blameVoteColumns + " FROM blame_votes WHERE blame_id = ? AND kind = ?"
+	vote, err := scanBlameVote(s.db.QueryRow(query, blame_id, kind))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return vote, err
+}
+
+func (s *SQLiteStore) ListOpenBlameVotes() ([]models.BlameVote, error) {
+	query := "SELECT " + blameVoteColumns + " FROM blame_votes WHERE status = 'open' ORDER BY ends_at"
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var votes []models.BlameVote
+	for rows.Next() {
+		vote, err := scanBlameVote(rows)
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, *vote)
+	}
+	return votes, rows.Err()
+}
+
+// SetBlameBallot records a user's ballot, replacing their previous one
+func (s *SQLiteStore) SetBlameBallot(vote_id string, user_id string, agree bool, cast_at time.Time) error {
+	query := `
+	INSERT INTO blame_vote_ballots (vote_id, user_id, agree, cast_at)
+	VALUES (?, ?, ?, ?)
+	ON CONFLICT(vote_id, user_id)
+	DO UPDATE SET
+		agree = excluded.agree,
+		cast_at = excluded.cast_at;
+	`
+	_, err := s.db.Exec(query, vote_id, user_id, agree, cast_at.UTC().Format(time.RFC3339))
+	return err
+}
+
+func (s *SQLiteStore) CountBlameBallots(vote_id string) (int, int, error) {
+	var agree, disagree int
+	query := "SELECT COALESCE(SUM(agree), 0), COALESCE(SUM(1 - agree), 0) FROM blame_vote_ballots WHERE vote_id = ?"
+	err := s.db.QueryRow(query, vote_id).Scan(&agree, &disagree)
+	return agree, disagree, err
+}
+
+func scanBlameVote(row rowScanner) (*models.BlameVote, error) {
+	var vote models.BlameVote
+	var ends_at, created_at string
+	err := row.Scan(
+		&vote.ID,
+		&vote.BlameID,
+		&vote.Kind,
+		&vote.GuildID,
+		&vote.ChannelID,
+		&vote.MessageID,
+		&vote.TargetID,
+		&vote.BlamerID,
+		&vote.Reason,
+		&vote.Threshold,
+		&vote.Status,
+		&vote.Locale,
+		&ends_at,
+		&created_at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vote.EndsAt, _ = time.Parse(time.RFC3339, ends_at)
+	vote.CreatedAt, _ = time.Parse(time.RFC3339, created_at)
+	return &vote, nil
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"database/sql"
+	"hiei-discord-bot/internal/models"
+	"time"
+)
+
+func (s *SQLiteStore) GetChipBalance(user_id string) (*models.ChipBalance, error) {
+	var balance int64
+	var last_refill string
+	query := "SELECT balance, last_refill FROM chip_balances WHERE user_id = ?"
+	err := s.db.QueryRow(query, user_id).Scan(&balance, &last_refill)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	last_refill_tmp, _ := time.Parse(time.RFC3339, last_refill)
+	return &models.ChipBalance{
+		UserID:     user_id,
+		Balance:    balance,
+		LastRefill: last_refill_tmp,
+	}, nil
+}
+
+func (s *SQLiteStore) SetChipBalance(balance models.ChipBalance) error {
+	query := `
+	INSERT INTO chip_balances (user_id, balance, last_refill)
+	VALUES (?, ?, ?)
+	ON CONFLICT(user_id)
+	DO UPDATE SET
+		balance = excluded.balance,
+		last_refill = excluded.last_refill;
+	`
+	_, err := s.db.Exec(query, balance.UserID, balance.Balance, balance.LastRefill.UTC().Format(time.RFC3339))
+	return err
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"database/sql"
+	"hiei-discord-bot/internal/models"
+	"strings"
+	"time"
+)
+
+const giveawayColumns = "id, guild_id, channel_id, message_id, host_id, prize, winner_count, required_role_id, locale, ends_at, ended, winner_ids, created_at"
+
+func (s *SQLiteStore) SaveGiveaway(giveaway models.Giveaway) error {
+	query := `
+	INSERT INTO giveaways (` + giveawayColumns + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(id)
+	DO UPDATE SET
+		message_id = excluded.message_id,
+		ends_at = excluded.ends_at,
+		ended = excluded.ended,
+		winner_ids = excluded.winner_ids;
+	`
+	_, err := s.db.Exec(query,
+		giveaway.ID,
+		giveaway.GuildID,
+		giveaway.ChannelID,
+		giveaway.MessageID,
+		giveaway.HostID,
+		giveaway.Prize,
+		giveaway.WinnerCount,
+		giveaway.RequiredRoleID,
+		giveaway.Locale,
+		giveaway.EndsAt.UTC().Format(time.RFC3339),
+		giveaway.Ended,
+		strings.Join(giveaway.WinnerIDs, ","),
+		giveaway.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	return err
+}
+
+func (s *SQLiteStore) GetGiveaway(id string) (*models.Giveaway, error) {
+	query := "SELECT " + giveawayColumns + " FROM giveaways WHERE id = ?"
+	giveaway, err := scanGiveaway(s.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return giveaway, err
+}
+
+func (s *SQLiteStore) ListActiveGiveaways(guild_id string) ([]models.Giveaway, error) {
+	query := "SELECT " + giveawayColumns + " FROM giveaways WHERE guild_id = ? AND ended = 0 ORDER BY ends_at"
+	return s.queryGiveaways(query, guild_id)
+}
+
+func (s *SQLiteStore) ListPendingGiveaways() ([]models.Giveaway, error) {
+	query := "SELECT " + giveawayColumns + " FROM giveaways WHERE ended = 0 ORDER BY ends_at"
+	return s.queryGiveaways(query)
+}
+
+func (s *SQLiteStore) AddGiveawayEntry(giveaway_id string, user_id string, entered_at time.Time) (bool, error) {
+	query := "INSERT OR IGNORE INTO giveaway_entries (giveaway_id, user_id, entered_at) VALUES (?, ?, ?)"
+	result, err := s.db.Exec(query, giveaway_id, user_id, entered_at.UTC().Format(time.RFC3339))
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (s *SQLiteStore) GetGiveawayEntries(giveaway_id string) ([]string, error) {
+	query := "SELECT user_id FROM giveaway_entries WHERE giveaway_id = ? ORDER BY entered_at, user_id"
+	rows, err := s.db.Query(query, giveaway_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var user_id string
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, user_id)
+	}
+	return userIDs, rows.Err()
+}
+
+func (s *SQLiteStore) CountGiveawayEntries(giveaway_id string) (int, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM giveaway_entries WHERE giveaway_id = ?"
+	err := s.db.QueryRow(query, giveaway_id).Scan(&count)
+	return count, err
+}
+
+func (s *SQLiteStore) queryGiveaways(query string, args ...interface{}) ([]models.Giveaway, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var giveaways []models.Giveaway
+	for rows.Next() {
+		giveaway, err := scanGiveaway(rows)
+		if err != nil {
+			return nil, err
+		}
+		giveaways = append(giveaways, *giveaway)
+	}
+	return giveaways, rows.Err()
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanGiveaway(row rowScanner) (*models.Giveaway, error) {
+	var giveaway models.Giveaway
+	var ends_at, winner_ids, created_at string
+	err := row.Scan(
+		&giveaway.ID,
+		&giveaway.GuildID,
+		&giveaway.ChannelID,
+		&giveaway.MessageID,
+		&giveaway.HostID,
+		&giveaway.Prize,
+		&giveaway.WinnerCount,
+		&giveaway.RequiredRoleID,
+		&giveaway.Locale,
+		&ends_at,
+		&giveaway.Ended,
+		&winner_ids,
+		&created_at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	giveaway.EndsAt, _ = time.Parse(time.RFC3339, ends_at)
+	giveaway.CreatedAt, _ = time.Parse(time.RFC3339, created_at)
+	if winner_ids != "" {
+		giveaway.WinnerIDs = strings.Split(winner_ids, ",")
+	}
+	return &giveaway, nil
+}
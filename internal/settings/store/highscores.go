@@ -0,0 +1,40 @@
+package store
+
+import (
+	"database/sql"
+	"hiei-discord-bot/internal/models"
+	"time"
+)
+
+func (s *SQLiteStore) GetBestScore(game string, user_id string) (*models.BestScore, error) {
+	var score int64
+	var achieved_at string
+	query := "SELECT score, achieved_at FROM best_scores WHERE game = ? AND user_id = ?"
+	err := s.db.QueryRow(query, game, user_id).Scan(&score, &achieved_at)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	achieved_at_tmp, _ := time.Parse(time.RFC3339, achieved_at)
+	return &models.BestScore{
+		Game:       game,
+		UserID:     user_id,
+		Score:      score,
+		AchievedAt: achieved_at_tmp,
+	}, nil
+}
+
+func (s *SQLiteStore) SetBestScore(score models.BestScore) error {
+	query := `
+	INSERT INTO best_scores (game, user_id, score, achieved_at)
+	VALUES (?, ?, ?, ?)
+	ON CONFLICT(game, user_id)
+	DO UPDATE SET
+		score = excluded.score,
+		achieved_at = excluded.achieved_at;
+	`
+	_, err := s.db.Exec(query, score.Game, score.UserID, score.Score, score.AchievedAt.UTC().Format(time.RFC3339))
+	return err
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"hiei-discord-bot/internal/models"
 	"hiei-discord-bot/internal/settings"
+	"net/url"
+	"os"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -39,11 +41,16 @@ func NewSQLiteStore(path string) (*SQLiteStore, error) {
 		command_name TEXT NOT NULL,
 		version TEXT NOT NULL,
 		build_time TEXT NOT NULL,
+		hash TEXT NOT NULL DEFAULT '',
 		PRIMARY KEY (command_name)
 	);`
 	if _, err := db.Exec(query); err != nil {
 		return nil, err
 	}
+	// Databases created before definition hashing lack the hash column
+	if err := addColumn(db, "local_command_versions", "hash", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return nil, err
+	}
 	// 3. guild_command_versions
 	query = `
 	CREATE TABLE IF NOT EXISTS guild_command_versions (
@@ -55,10 +62,183 @@ func NewSQLiteStore(path string) (*SQLiteStore, error) {
 	if _, err := db.Exec(query); err != nil {
 		return nil, err
 	}
+	// 4. chip_balances
+	query = `
+	CREATE TABLE IF NOT EXISTS chip_balances (
+		user_id TEXT NOT NULL,
+		balance INTEGER NOT NULL,
+		last_refill TEXT NOT NULL,
+		PRIMARY KEY (user_id)
+	);`
+	if _, err := db.Exec(query); err != nil {
+		return nil, err
+	}
+	// 5. best_scores
+	query = `
+	CREATE TABLE IF NOT EXISTS best_scores (
+		game TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		score INTEGER NOT NULL,
+		achieved_at TEXT NOT NULL,
+		PRIMARY KEY (game, user_id)
+	);`
+	if _, err := db.Exec(query); err != nil {
+		return nil, err
+	}
+	// 6. giveaways
+	query = `
+	CREATE TABLE IF NOT EXISTS giveaways (
+		id TEXT NOT NULL,
+		guild_id TEXT NOT NULL,
+		channel_id TEXT NOT NULL,
+		message_id TEXT NOT NULL,
+		host_id TEXT NOT NULL,
+		prize TEXT NOT NULL,
+		winner_count INTEGER NOT NULL,
+		required_role_id TEXT NOT NULL,
+		locale TEXT NOT NULL,
+		ends_at TEXT NOT NULL,
+		ended INTEGER NOT NULL,
+		winner_ids TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		PRIMARY KEY (id)
+	);`
+	if _, err := db.Exec(query); err != nil {
+		return nil, err
+	}
+	// 7. giveaway_entries
+	query = `
+	CREATE TABLE IF NOT EXISTS giveaway_entries (
+		giveaway_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		entered_at TEXT NOT NULL,
+		PRIMARY KEY (giveaway_id, user_id)
+	);`
+	if _, err := db.Exec(query); err != nil {
+		return nil, err
+	}
+	// 8. blames
+	query = `
+	CREATE TABLE IF NOT EXISTS blames (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		guild_id TEXT NOT NULL,
+		channel_id TEXT NOT NULL,
+		message_id TEXT NOT NULL,
+		blamer_id TEXT NOT NULL,
+		target_id TEXT NOT NULL,
+		reason TEXT NOT NULL,
+		created_at TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_blames_guild_target ON blames (guild_id, target_id);
+	CREATE INDEX IF NOT EXISTS idx_blames_guild_created ON blames (guild_id, created_at);`
+	if _, err := db.Exec(query); err != nil {
+		return nil, err
+	}
+
+	// 9. blame_images
+	query = `
+	CREATE TABLE IF NOT EXISTS blame_images (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		guild_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		content_type TEXT NOT NULL,
+		data BLOB NOT NULL,
+		uploader_id TEXT NOT NULL,
+		created_at TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_blame_images_guild ON blame_images (guild_id);`
+	if _, err := db.Exec(query); err != nil {
+		return nil, err
+	}
+
+	// 10. blame_votes
+	query = `
+	CREATE TABLE IF NOT EXISTS blame_votes (
+		id TEXT PRIMARY KEY,
+		blame_id INTEGER NOT NULL,
+		kind TEXT NOT NULL,
+		guild_id TEXT NOT NULL,
+		channel_id TEXT NOT NULL,
+		message_id TEXT NOT NULL,
+		target_id TEXT NOT NULL,
+		blamer_id TEXT NOT NULL,
+		reason TEXT NOT NULL,
+		threshold INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		locale TEXT NOT NULL,
+		ends_at TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		UNIQUE (blame_id, kind)
+	);`
+	if _, err := db.Exec(query); err != nil {
+		return nil, err
+	}
+
+	// 11. blame_vote_ballots
+	query = `
+	CREATE TABLE IF NOT EXISTS blame_vote_ballots (
+		vote_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		agree INTEGER NOT NULL,
+		cast_at TEXT NOT NULL,
+		PRIMARY KEY (vote_id, user_id)
+	);`
+	if _, err := db.Exec(query); err != nil {
+		return nil, err
+	}
 
 	return &SQLiteStore{db: db}, nil
 }
 
+// OpenSQLiteStore opens an existing database without creating or upgrading its
+// tables, so it fails if the file is missing. A read-only store cannot change
+// the database at all.
+func OpenSQLiteStore(path string, readOnly bool) (*SQLiteStore, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	dsn := path
+	if readOnly {
+		dsn = "file:" + (&url.URL{Path: path}).EscapedPath() + "?mode=ro"
+	}
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// Backup writes a consistent copy of the database to path, which must not exist
+func (s *SQLiteStore) Backup(path string) error {
+	_, err := s.db.Exec("VACUUM INTO ?", path)
+	return err
+}
+
+// Close closes the database
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// addColumn adds a column to an existing table unless it is already there
+func addColumn(db *sql.DB, table, column, definition string) error {
+	var count int
+	query := "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?"
+	if err := db.QueryRow(query, table, column).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	_, err := db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition)
+	return err
+}
+
 func (s *SQLiteStore) GetSetting(scope settings.SettingScope, targetID, key string) (string, error) {
 	var value string
 	query := "SELECT value FROM settings WHERE scope = ? AND target_id = ? AND key = ?"
@@ -80,29 +260,32 @@ func (s *SQLiteStore) SetSetting(scope settings.SettingScope, targetID, key, val
 
 func (s *SQLiteStore) GetLocalCommandVersionAndBuildTime(command_name string) (models.CommandVersion, error) {
 	var version string
+	var hash string
 	var build_time string
-	query := "SELECT version, build_time FROM local_command_versions WHERE command_name = ?"
-	err := s.db.QueryRow(query, command_name).Scan(&version, &build_time)
+	query := "SELECT version, hash, build_time FROM local_command_versions WHERE command_name = ?"
+	err := s.db.QueryRow(query, command_name).Scan(&version, &hash, &build_time)
 	if err == sql.ErrNoRows {
 		return models.CommandVersion{}, nil
 	}
 	build_time_tmp, _ := time.Parse(time.RFC3339, build_time)
 	return models.CommandVersion{
 		Version:   version,
+		Hash:      hash,
 		BuildTime: build_time_tmp,
 	}, err
 }
 
 func (s *SQLiteStore) UpdateLocalCommandVersionAndBuildTime(command_name string, command_version models.CommandVersion) error {
 	query := `
-	INSERT INTO local_command_versions (command_name, version, build_time)
-	VALUES (?, ?, ?)
+	INSERT INTO local_command_versions (command_name, version, build_time, hash)
+	VALUES (?, ?, ?, ?)
 	ON CONFLICT(command_name)
 	DO UPDATE SET
 		version = excluded.version,
-		build_time = excluded.build_time;
+		build_time = excluded.build_time,
+		hash = excluded.hash;
 	`
-	_, err := s.db.Exec(query, command_name, command_version.Version, command_version.BuildTime.Format(time.RFC3339))
+	_, err := s.db.Exec(query, command_name, command_version.Version, command_version.BuildTime.Format(time.RFC3339), command_version.Hash)
 	return err
 }
 
@@ -159,3 +342,22 @@ func (s *SQLiteStore) DeleteGuildCommandVersion(guild_id string, command_name st
 	_, err := s.db.Exec(query, guild_id, command_name)
 	return err
 }
+
+func (s *SQLiteStore) GetGuildCommandNames(guild_id string) ([]string, error) {
+	query := "SELECT command_name FROM guild_command_versions WHERE guild_id = ?"
+	rows, err := s.db.Query(query, guild_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
@@ -3,14 +3,20 @@ package main
 import (
 	"log/slog"
 	"os"
-	"strings"
 
 	"hiei-discord-bot/internal/bot"
+	"hiei-discord-bot/internal/cli"
 	"hiei-discord-bot/internal/config"
 	"hiei-discord-bot/internal/i18n"
+	"hiei-discord-bot/internal/logging"
 )
 
 func main() {
+	// Management subcommands run without connecting to the gateway
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(os.Args[1:]))
+	}
+
 	// Load configuration to get log level
 	cfg, err := config.Load()
 	if err != nil {
@@ -18,34 +24,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Parse log level from config and reconfigure logger
-	var logLevel slog.Level
-	switch strings.ToUpper(cfg.LogLevel) {
-	case "DEBUG":
-		logLevel = slog.LevelDebug
-	case "INFO":
-		logLevel = slog.LevelInfo
-	case "WARN":
-		logLevel = slog.LevelWarn
-	case "ERROR":
-		logLevel = slog.LevelError
-	default:
-		logLevel = slog.LevelInfo
-	}
-
 	// Reconfigure logger with the configured level
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: logLevel,
-	}))
-	slog.SetDefault(logger)
+	logging.Setup(cfg.LogLevel)
 
 	slog.Info("Starting Discord Bot...", "log_level", cfg.LogLevel)
 
 	// Load translations
-	if err := i18n.LoadTranslations(); err != nil {
+	if err := i18n.LoadTranslationsFrom(cfg.I18nDir); err != nil {
 		slog.Error("Failed to load translations", "error", err)
 		os.Exit(1)
 	}
+	i18n.SetDefaultLocale(i18n.SupportedLocale(cfg.DefaultLocale))
 
 	// Create bot instance
 	b, err := bot.New(cfg)
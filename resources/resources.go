@@ -17,3 +17,35 @@ var Images embed.FS
 
 // ImagesBasePath is the base path for image resources within the embedded filesystem
 const ImagesBasePath = "images"
+
+// HangmanWordPacks contains all embedded hangman word packs, one per language
+//
+//go:embed hangman/*.json
+var HangmanWordPacks embed.FS
+
+// HangmanBasePath is the base path for hangman word packs within the embedded filesystem
+const HangmanBasePath = "hangman"
+
+// TriviaBanks contains all embedded trivia question banks, one per locale
+//
+//go:embed trivia/*.json
+var TriviaBanks embed.FS
+
+// TriviaBasePath is the base path for trivia question banks within the embedded filesystem
+const TriviaBasePath = "trivia"
+
+// Wordlists contains the embedded passphrase word lists
+//
+//go:embed wordlists/*.txt
+var Wordlists embed.FS
+
+// WordlistsBasePath is the base path for word lists within the embedded filesystem
+const WordlistsBasePath = "wordlists"
+
+// Fonts contains the embedded fonts used to render images
+//
+//go:embed fonts/*.otf
+var Fonts embed.FS
+
+// FontsBasePath is the base path for fonts within the embedded filesystem
+const FontsBasePath = "fonts"